@@ -0,0 +1,97 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is an error returned by the Deno Deploy API.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the error code in the response body, such as `projectNotFound`.
+	// It is empty if the body couldn't be decoded.
+	Code string
+	// Message is the error message in the response body. It is empty if the
+	// body couldn't be decoded.
+	Message string
+	// TraceID is the value of the x-deno-ray header, which the support team
+	// can use to look into the request.
+	TraceID string
+	// Body is the raw response body.
+	Body []byte
+}
+
+// NewAPIError builds an APIError from the given response and its body.
+func NewAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		TraceID:    resp.Header.Get(X_DENO_RAY),
+		Body:       body,
+	}
+
+	var errorBody ErrorBody
+	if err := json.Unmarshal(body, &errorBody); err == nil {
+		apiErr.Code = errorBody.Code
+		apiErr.Message = errorBody.Message
+	}
+
+	return apiErr
+}
+
+// CheckResponse returns an *APIError if the response status code is >= 400,
+// and nil otherwise.
+func CheckResponse(resp APIResponse) error {
+	httpResp := resp.GetHTTPResponse()
+	if httpResp == nil {
+		return errors.New("failed to extract API error detail: no response")
+	}
+	if httpResp.StatusCode < 400 {
+		return nil
+	}
+	return NewAPIError(httpResp, resp.GetBody())
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	traceID := e.TraceID
+	if traceID == "" {
+		traceID = "<unknown>"
+	}
+
+	return fmt.Sprintf("API request errored with status code %d.\nResponse body: %s\n\nPlease contact the support team with the ID: %s.", e.StatusCode, e.Body, traceID)
+}
+
+// IsNotFound returns true if err is an *APIError telling that the requested
+// resource does not exist. A 404 response whose body is not an API error, such
+// as the one returned by a proxy in front of the API, is not regarded as such.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound && apiErr.Code != ""
+}
+
+// IsUnauthorized returns true if err is an *APIError telling that the access
+// token is missing, invalid or expired.
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsConflict returns true if err is an *APIError telling that the request
+// conflicts with the current state of a resource, e.g. the name is already
+// taken.
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsRateLimited returns true if err is an *APIError telling that too many
+// requests have been sent.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+func hasStatusCode(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		body            string
+		expectedCode    string
+		expectedMessage string
+		notFound        bool
+		unauthorized    bool
+		conflict        bool
+		rateLimited     bool
+	}{
+		{
+			name:       "ok",
+			statusCode: 200,
+			body:       `{"id":"foo"}`,
+		},
+		{
+			name:            "not found",
+			statusCode:      404,
+			body:            `{"code":"projectNotFound","message":"The requested project was not found."}`,
+			expectedCode:    "projectNotFound",
+			expectedMessage: "The requested project was not found.",
+			notFound:        true,
+		},
+		{
+			name:       "not found without API error body",
+			statusCode: 404,
+			body:       `<html>Not Found</html>`,
+		},
+		{
+			name:            "unauthorized",
+			statusCode:      401,
+			body:            `{"code":"unauthorized","message":"Invalid token"}`,
+			expectedCode:    "unauthorized",
+			expectedMessage: "Invalid token",
+			unauthorized:    true,
+		},
+		{
+			name:            "conflict",
+			statusCode:      409,
+			body:            `{"code":"projectNameInUse","message":"The name is already taken"}`,
+			expectedCode:    "projectNameInUse",
+			expectedMessage: "The name is already taken",
+			conflict:        true,
+		},
+		{
+			name:        "rate limited",
+			statusCode:  429,
+			body:        ``,
+			rateLimited: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &GetProjectResponse{
+				Body: []byte(test.body),
+				HTTPResponse: &http.Response{
+					StatusCode: test.statusCode,
+					Header:     http.Header{http.CanonicalHeaderKey(X_DENO_RAY): []string{"ray"}},
+				},
+			}
+			if resp.TraceID() != "ray" {
+				t.Errorf("Expected trace ID ray, got %s", resp.TraceID())
+			}
+
+			err := CheckResponse(resp)
+			if test.statusCode < 400 {
+				if err != nil {
+					t.Fatalf("Expected no error, got %s", err)
+				}
+				return
+			}
+
+			apiErr, ok := err.(*APIError)
+			if !ok {
+				t.Fatalf("Expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != test.statusCode || apiErr.Code != test.expectedCode || apiErr.Message != test.expectedMessage || apiErr.TraceID != "ray" {
+				t.Errorf("Unexpected error: %+v", apiErr)
+			}
+
+			// The helpers see through wrapped errors
+			wrapped := fmt.Errorf("wrapped: %w", err)
+			if IsNotFound(wrapped) != test.notFound {
+				t.Errorf("IsNotFound: expected %t", test.notFound)
+			}
+			if IsUnauthorized(wrapped) != test.unauthorized {
+				t.Errorf("IsUnauthorized: expected %t", test.unauthorized)
+			}
+			if IsConflict(wrapped) != test.conflict {
+				t.Errorf("IsConflict: expected %t", test.conflict)
+			}
+			if IsRateLimited(wrapped) != test.rateLimited {
+				t.Errorf("IsRateLimited: expected %t", test.rateLimited)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Command genresponses generates methods that make every response type in the
+// generated client implement the client.APIResponse interface.
+//
+// oapi-codegen doesn't provide a way to access the body and headers of a
+// response generically (see https://github.com/deepmap/oapi-codegen/issues/240),
+// so this command parses client.go, finds every struct with `Body` and
+// `HTTPResponse` fields, and writes the accessor methods to a companion file.
+//
+// Usage: go run ./internal/genresponses -in client.go -out response.gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+)
+
+func main() {
+	in := flag.String("in", "client.go", "the generated client to read")
+	out := flag.String("out", "response.gen.go", "the file to write the methods to")
+	flag.Parse()
+
+	names, err := findResponseTypes(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// findResponseTypes returns the names of the structs that have both `Body`
+// and `HTTPResponse` fields, sorted alphabetically.
+func findResponseTypes(filename string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+
+			fields := map[string]bool{}
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					fields[name.Name] = true
+				}
+			}
+			if fields["Body"] && fields["HTTPResponse"] {
+				names = append(names, typeSpec.Name.Name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no response types found in %s", filename)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+func generate(names []string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "// Code generated by genresponses. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package client")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, `import "net/http"`)
+
+	for _, name := range names {
+		fmt.Fprintf(&buf, `
+var _ APIResponse = (*%[1]s)(nil)
+
+// GetBody returns the raw response body.
+func (r %[1]s) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r %[1]s) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r %[1]s) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r %[1]s) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+`, name)
+	}
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	// MAX_PAGE_SIZE is the maximum number of items the API returns per page,
+	// which the iterators request to minimize the number of round trips.
+	MAX_PAGE_SIZE = 100
+)
+
+// ParseLinkHeader parses the value of an RFC 8288 Link header, such as
+// PaginationLinkHeader or CursorLinkHeader, and returns the target URLs keyed
+// by their relation types, e.g. "next". Links that fail to parse are skipped.
+func ParseLinkHeader(header string) map[string]*url.URL {
+	links := map[string]*url.URL{}
+
+	rest := header
+	for {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start:], '>')
+		if end < 0 {
+			break
+		}
+		target := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		// The parameters extend up to the next link
+		params := rest
+		if next := strings.IndexByte(rest, '<'); next >= 0 {
+			params = rest[:next]
+		}
+
+		u, err := url.Parse(target)
+		if err != nil {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+				continue
+			}
+			value = strings.Trim(strings.TrimSpace(value), `",`)
+			// A rel parameter may hold several space-separated relation types
+			for _, rel := range strings.Fields(value) {
+				links[strings.ToLower(rel)] = u
+			}
+		}
+	}
+
+	return links
+}
+
+// pageFetcher fetches a page of a list. It returns the items in the page and
+// the headers of the response.
+type pageFetcher[T any] func(ctx context.Context, page int, limit int) ([]T, http.Header, error)
+
+// Iterator walks every item of a paginated list. Pages are fetched lazily, i.e.
+// the next page is requested only once the items of the current page have
+// been consumed, by following the "next" link of the Link header.
+//
+// Use it like bufio.Scanner:
+//
+//	it := client.ListAllProjects(ctx, c, organizationID)
+//	for it.Next() {
+//		project := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFetcher[T]
+
+	page  int
+	limit int
+	// lastPage is set once the page without the next link is fetched.
+	lastPage bool
+
+	items   []T
+	current T
+	err     error
+}
+
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		page:  1,
+		limit: MAX_PAGE_SIZE,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false when there are no more items, or when an error
+// occurred, including the cancellation of the context. Err tells which.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.items) == 0 {
+		if it.lastPage {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	it.current = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+func (it *Iterator[T]) fetchPage() error {
+	items, header, err := it.fetch(it.ctx, it.page, it.limit)
+	if err != nil {
+		return err
+	}
+	it.items = items
+
+	linkHeader := header.Get("Link")
+	if linkHeader == "" {
+		// Without links, a short page is the last one
+		it.lastPage = len(items) < it.limit
+		it.page++
+		return nil
+	}
+
+	next, ok := ParseLinkHeader(linkHeader)["next"]
+	if !ok {
+		it.lastPage = true
+		return nil
+	}
+	query := next.Query()
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= it.page {
+		// Guard against links that would never reach the end
+		page = it.page + 1
+	}
+	it.page = page
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		it.limit = limit
+	}
+	return nil
+}
+
+// Value returns the current item. It is valid only after Next returned true.
+func (it *Iterator[T]) Value() T {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any. An error returned
+// by the API is an *APIError.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All consumes the iterator and returns all the remaining items.
+func (it *Iterator[T]) All() ([]T, error) {
+	var ret []T
+	for it.Next() {
+		ret = append(ret, it.Value())
+	}
+	return ret, it.Err()
+}
+
+// ListAllProjects returns an iterator over all the projects of the
+// organization.
+func ListAllProjects(ctx context.Context, c ClientWithResponsesInterface, organizationId openapi_types.UUID) *Iterator[Project] {
+	return newIterator(ctx, func(ctx context.Context, page int, limit int) ([]Project, http.Header, error) {
+		resp, err := c.ListProjectsWithResponse(ctx, organizationId, &ListProjectsParams{
+			Page:  &page,
+			Limit: &limit,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := CheckResponse(resp); err != nil {
+			return nil, nil, err
+		}
+		return derefSlice(resp.JSON200), resp.GetHeaders(), nil
+	})
+}
+
+// ListAllDomains returns an iterator over all the domains of the
+// organization.
+func ListAllDomains(ctx context.Context, c ClientWithResponsesInterface, organizationId openapi_types.UUID) *Iterator[Domain] {
+	return newIterator(ctx, func(ctx context.Context, page int, limit int) ([]Domain, http.Header, error) {
+		resp, err := c.ListDomainsWithResponse(ctx, organizationId, &ListDomainsParams{
+			Page:  &page,
+			Limit: &limit,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := CheckResponse(resp); err != nil {
+			return nil, nil, err
+		}
+		return derefSlice(resp.JSON200), resp.GetHeaders(), nil
+	})
+}
+
+// ListAllDeployments returns an iterator over all the deployments of the
+// project.
+func ListAllDeployments(ctx context.Context, c ClientWithResponsesInterface, projectId openapi_types.UUID) *Iterator[Deployment] {
+	return newIterator(ctx, func(ctx context.Context, page int, limit int) ([]Deployment, http.Header, error) {
+		resp, err := c.ListDeploymentsWithResponse(ctx, projectId, &ListDeploymentsParams{
+			Page:  &page,
+			Limit: &limit,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := CheckResponse(resp); err != nil {
+			return nil, nil, err
+		}
+		return derefSlice(resp.JSON200), resp.GetHeaders(), nil
+	})
+}
+
+func derefSlice[T any](s *[]T) []T {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `<https://api.deno.com/v1/organizations/foo/projects?page=3&limit=2>; rel="next", ` +
+		`<https://api.deno.com/v1/organizations/foo/projects?page=1&limit=2>; rel="prev first",` +
+		`<https://api.deno.com/v1/organizations/foo/projects?page=5&limit=2>;rel=last, <invalid`
+
+	links := ParseLinkHeader(header)
+	expected := map[string]string{
+		"next":  "page=3&limit=2",
+		"prev":  "page=1&limit=2",
+		"first": "page=1&limit=2",
+		"last":  "page=5&limit=2",
+	}
+	if len(links) != len(expected) {
+		t.Errorf("Expected %d links, got %v", len(expected), links)
+	}
+	for rel, query := range expected {
+		link, ok := links[rel]
+		if !ok {
+			t.Errorf("Missing %s link", rel)
+			continue
+		}
+		if link.RawQuery != query {
+			t.Errorf("Expected %s link to have query %s, got %s", rel, query, link.RawQuery)
+		}
+	}
+}
+
+// newPaginatedServer serves n projects, limit per page, optionally with Link
+// headers. It counts the requests in requests.
+func newPaginatedServer(t *testing.T, n int, withLinks bool, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if page < 1 || limit < 1 {
+			t.Errorf("Unexpected query: %s", r.URL.RawQuery)
+		}
+		// The server may return fewer items than requested
+		limit = min(limit, 2)
+
+		start := min((page-1)*limit, n)
+		end := min(start+limit, n)
+		if withLinks && end < n {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d&limit=%d>; rel="next"`, r.Host, r.URL.Path, page+1, limit))
+		} else if withLinks {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=1&limit=%d>; rel="first"`, r.Host, r.URL.Path, limit))
+		}
+
+		projects := []Project{}
+		for i := start; i < end; i++ {
+			projects = append(projects, Project{Name: fmt.Sprintf("project-%d", i)})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(projects)
+	}))
+}
+
+func TestListAllProjects(t *testing.T) {
+	tests := []struct {
+		name             string
+		n                int
+		withLinks        bool
+		expectedRequests int
+	}{
+		{name: "links", n: 5, withLinks: true, expectedRequests: 3},
+		{name: "links with full last page", n: 4, withLinks: true, expectedRequests: 2},
+		{name: "no links", n: 5, withLinks: false, expectedRequests: 1},
+		{name: "empty", n: 0, withLinks: true, expectedRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := newPaginatedServer(t, tt.n, tt.withLinks, &requests)
+			defer server.Close()
+
+			c, err := NewClientWithResponses(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			projects, err := ListAllProjects(context.Background(), c, uuid.New()).All()
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedItems := tt.n
+			if !tt.withLinks {
+				// A short page is taken as the last one
+				expectedItems = min(tt.n, 2)
+			}
+			if len(projects) != expectedItems {
+				t.Errorf("Expected %d projects, got %d", expectedItems, len(projects))
+			}
+			for i, project := range projects {
+				if project.Name != fmt.Sprintf("project-%d", i) {
+					t.Errorf("Unexpected project at %d: %s", i, project.Name)
+				}
+			}
+			if requests != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestIteratorIsLazy(t *testing.T) {
+	requests := 0
+	server := newPaginatedServer(t, 10, true, &requests)
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	it := ListAllProjects(ctx, c, uuid.New())
+	for i := 0; i < 3; i++ {
+		if !it.Next() {
+			t.Fatalf("Expected project %d, got error %v", i, it.Err())
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests for 3 projects, got %d", requests)
+	}
+
+	cancel()
+	if it.Next() {
+		t.Errorf("Expected the iteration to stop after cancellation")
+	}
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", it.Err())
+	}
+	if requests != 2 {
+		t.Errorf("Expected no more requests after cancellation, got %d", requests)
+	}
+}
+
+func TestIteratorAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code":"organizationNotFound","message":"The requested organization was not found."}`)
+	}))
+	defer server.Close()
+
+	c, err := NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it := ListAllDomains(context.Background(), c, uuid.New())
+	if it.Next() {
+		t.Errorf("Expected no domains")
+	}
+	if !IsNotFound(it.Err()) {
+		t.Errorf("Expected not found error, got %v", it.Err())
+	}
+}
@@ -0,0 +1,505 @@
+// Code generated by genresponses. DO NOT EDIT.
+
+package client
+
+import "net/http"
+
+var _ APIResponse = (*AddDomainCertificateResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r AddDomainCertificateResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r AddDomainCertificateResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r AddDomainCertificateResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r AddDomainCertificateResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*CreateDeploymentResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r CreateDeploymentResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r CreateDeploymentResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r CreateDeploymentResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r CreateDeploymentResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*CreateDomainResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r CreateDomainResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r CreateDomainResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r CreateDomainResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r CreateDomainResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*CreateProjectResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r CreateProjectResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r CreateProjectResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r CreateProjectResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r CreateProjectResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*DeleteDomainResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r DeleteDomainResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r DeleteDomainResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r DeleteDomainResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r DeleteDomainResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*DeleteProjectResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r DeleteProjectResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r DeleteProjectResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r DeleteProjectResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r DeleteProjectResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetAppLogsResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetAppLogsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetAppLogsResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetAppLogsResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetAppLogsResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetBuildLogsResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetBuildLogsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetBuildLogsResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetBuildLogsResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetBuildLogsResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetDeploymentResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetDeploymentResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetDeploymentResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetDeploymentResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetDeploymentResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetDomainResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetDomainResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetDomainResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetDomainResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetDomainResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetOrganizationResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetOrganizationResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetOrganizationResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetOrganizationResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetOrganizationResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetProjectAnalyticsResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetProjectAnalyticsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetProjectAnalyticsResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetProjectAnalyticsResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetProjectAnalyticsResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*GetProjectResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r GetProjectResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r GetProjectResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r GetProjectResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r GetProjectResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*ListDeploymentsResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r ListDeploymentsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r ListDeploymentsResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r ListDeploymentsResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r ListDeploymentsResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*ListDomainsResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r ListDomainsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r ListDomainsResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r ListDomainsResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r ListDomainsResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*ListProjectsResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r ListProjectsResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r ListProjectsResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r ListProjectsResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r ListProjectsResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*ProvisionDomainCertificatesResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r ProvisionDomainCertificatesResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r ProvisionDomainCertificatesResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r ProvisionDomainCertificatesResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r ProvisionDomainCertificatesResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*UpdateDomainAssociationResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r UpdateDomainAssociationResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r UpdateDomainAssociationResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r UpdateDomainAssociationResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r UpdateDomainAssociationResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*UpdateProjectResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r UpdateProjectResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r UpdateProjectResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r UpdateProjectResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r UpdateProjectResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
+
+var _ APIResponse = (*VerifyDomainResponse)(nil)
+
+// GetBody returns the raw response body.
+func (r VerifyDomainResponse) GetBody() []byte {
+	return r.Body
+}
+
+// GetHTTPResponse returns the underlying HTTP response.
+func (r VerifyDomainResponse) GetHTTPResponse() *http.Response {
+	return r.HTTPResponse
+}
+
+// GetHeaders returns the response headers.
+func (r VerifyDomainResponse) GetHeaders() http.Header {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Header
+	}
+	return http.Header{}
+}
+
+// TraceID returns the value of the x-deno-ray response header.
+func (r VerifyDomainResponse) TraceID() string {
+	return r.GetHeaders().Get(X_DENO_RAY)
+}
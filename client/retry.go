@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DEFAULT_MAX_RETRIES is the default number of times a failed request is
+	// retried.
+	DEFAULT_MAX_RETRIES = 3
+	// DEFAULT_MAX_RETRY_WAIT is the default upper bound of the time to wait
+	// before retrying a failed request.
+	DEFAULT_MAX_RETRY_WAIT = 30 * time.Second
+
+	// retryBaseWait is the wait before the first retry, which is doubled for
+	// every subsequent retry.
+	retryBaseWait = 1 * time.Second
+)
+
+// RetryConfig configures the behavior of RetryingDoer.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries after the initial attempt.
+	// Zero disables retrying.
+	MaxRetries int
+	// MaxWait is the upper bound of the time to wait before each retry. The
+	// wait given by a Retry-After header is capped at this value as well.
+	MaxWait time.Duration
+	// RetryNonIdempotent enables retrying requests whose method is not
+	// idempotent, such as POST and PATCH. Retrying them may cause the
+	// operation to be applied more than once.
+	RetryNonIdempotent bool
+	// Logger, if set, is called for every attempt failed due to a transient
+	// error, including the last one that is not retried, so that the trace ID
+	// of the failure returned to the caller is logged as well.
+	Logger func(ctx context.Context, msg string, fields map[string]any)
+}
+
+// RetryingDoer is an HttpRequestDoer that retries requests failed due to
+// transient errors, i.e. connection errors, 429 and 5xx responses except 501,
+// with exponential backoff and jitter.
+type RetryingDoer struct {
+	doer   HttpRequestDoer
+	config RetryConfig
+}
+
+// NewRetryingDoer wraps the given doer so that failed requests are retried
+// according to config.
+func NewRetryingDoer(doer HttpRequestDoer, config RetryConfig) *RetryingDoer {
+	return &RetryingDoer{
+		doer:   doer,
+		config: config,
+	}
+}
+
+// Do sends the request, retrying it on transient errors.
+func (d *RetryingDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := d.config.RetryNonIdempotent || isIdempotent(req.Method)
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The body has been consumed by the previous attempt
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("cannot retry %s %s: request body is not rewindable", req.Method, req.URL)
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := d.doer.Do(req)
+		if !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+		if attempt >= d.config.MaxRetries || !retryable {
+			d.log(ctx, "Deno Deploy API request failed, giving up", req, attempt, resp, err, map[string]any{})
+			return resp, err
+		}
+
+		wait := backoff(attempt, d.config.MaxWait)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = min(retryAfter, d.config.MaxWait)
+			}
+		}
+
+		d.log(ctx, "Deno Deploy API request failed, retrying", req, attempt, resp, err, map[string]any{
+			"wait": wait.String(),
+		})
+
+		// Discard the response of the failed attempt so that the connection
+		// can be reused
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// log calls the logger, if any, with the details of the failed attempt added
+// to fields.
+func (d *RetryingDoer) log(ctx context.Context, msg string, req *http.Request, attempt int, resp *http.Response, err error, fields map[string]any) {
+	if d.config.Logger == nil {
+		return
+	}
+	fields["method"] = req.Method
+	fields["url"] = req.URL.String()
+	fields["attempt"] = attempt + 1
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	if resp != nil {
+		fields["status_code"] = resp.StatusCode
+		fields["trace_id"] = resp.Header.Get(X_DENO_RAY)
+	}
+	d.config.Logger(ctx, msg, fields)
+}
+
+// isIdempotent returns true if the HTTP method is idempotent as defined in
+// RFC 9110, section 9.2.2.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry returns true if the attempt failed due to a transient error.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		// Errors caused by the cancellation of the request itself are final
+		return ctx.Err() == nil
+	}
+
+	return isTransientStatus(resp.StatusCode)
+}
+
+// isTransientStatus returns true if the status code tells that the request
+// may succeed if sent again later.
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the retry following the given attempt,
+// which is drawn uniformly from [0, min(maxWait, retryBaseWait * 2^attempt)].
+func backoff(attempt int, maxWait time.Duration) time.Duration {
+	wait := maxWait
+	// Guard against overflow for large attempts
+	if attempt < 32 {
+		wait = min(retryBaseWait<<attempt, maxWait)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(wait) + 1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
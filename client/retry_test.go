@@ -0,0 +1,166 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryingDoer(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		statuses         []int
+		config           RetryConfig
+		expectedStatus   int
+		expectedAttempts int
+		// expectedLogged is the number of failed attempts logged, including
+		// the last one that is not retried
+		expectedLogged int
+	}{
+		{
+			name:             "success",
+			method:           http.MethodGet,
+			statuses:         []int{200},
+			config:           RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond},
+			expectedStatus:   200,
+			expectedAttempts: 1,
+			expectedLogged:   0,
+		},
+		{
+			name:             "retry until success",
+			method:           http.MethodGet,
+			statuses:         []int{429, 502, 200},
+			config:           RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond},
+			expectedStatus:   200,
+			expectedAttempts: 3,
+			expectedLogged:   2,
+		},
+		{
+			name:             "give up after max retries",
+			method:           http.MethodDelete,
+			statuses:         []int{503, 503, 503, 503},
+			config:           RetryConfig{MaxRetries: 2, MaxWait: time.Millisecond},
+			expectedStatus:   503,
+			expectedAttempts: 3,
+			expectedLogged:   3,
+		},
+		{
+			name:             "client error is not retried",
+			method:           http.MethodGet,
+			statuses:         []int{404, 200},
+			config:           RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond},
+			expectedStatus:   404,
+			expectedAttempts: 1,
+			expectedLogged:   0,
+		},
+		{
+			name:             "non-idempotent method is not retried",
+			method:           http.MethodPost,
+			statuses:         []int{502, 200},
+			config:           RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond},
+			expectedStatus:   502,
+			expectedAttempts: 1,
+			expectedLogged:   1,
+		},
+		{
+			name:             "non-idempotent method is retried if enabled",
+			method:           http.MethodPost,
+			statuses:         []int{502, 200},
+			config:           RetryConfig{MaxRetries: 3, MaxWait: time.Millisecond, RetryNonIdempotent: true},
+			expectedStatus:   200,
+			expectedAttempts: 2,
+			expectedLogged:   1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != "payload" {
+					t.Errorf("Unexpected body in attempt %d: %q", attempts+1, body)
+				}
+				w.Header().Set(X_DENO_RAY, "ray")
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(test.statuses[attempts])
+				attempts++
+			}))
+			defer server.Close()
+
+			var logged int
+			test.config.Logger = func(ctx context.Context, msg string, fields map[string]any) {
+				logged++
+				if fields["trace_id"] != "ray" {
+					t.Errorf("Expected trace ID to be logged, got %v", fields["trace_id"])
+				}
+			}
+
+			req, err := http.NewRequest(test.method, server.URL, bytes.NewReader([]byte("payload")))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := NewRetryingDoer(http.DefaultClient, test.config).Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != test.expectedStatus {
+				t.Errorf("Expected status %d, got %d", test.expectedStatus, resp.StatusCode)
+			}
+			if attempts != test.expectedAttempts {
+				t.Errorf("Expected %d attempts, got %d", test.expectedAttempts, attempts)
+			}
+			if logged != test.expectedLogged {
+				t.Errorf("Expected %d failed attempts to be logged, got %d", test.expectedLogged, logged)
+			}
+		})
+	}
+}
+
+func TestRetryingDoerCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NewRetryingDoer(http.DefaultClient, RetryConfig{MaxRetries: 10, MaxWait: time.Hour}).Do(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{value: "", ok: false},
+		{value: "3", expected: 3 * time.Second, ok: true},
+		{value: "-1", ok: false},
+		{value: "Mon, 02 Jan 2006 15:04:05 GMT", expected: 0, ok: true},
+		{value: "soon", ok: false},
+	}
+
+	for _, test := range tests {
+		actual, ok := parseRetryAfter(test.value)
+		if ok != test.ok || actual != test.expected {
+			t.Errorf("parseRetryAfter(%q) = (%s, %t), expected (%s, %t)", test.value, actual, ok, test.expected, test.ok)
+		}
+	}
+}
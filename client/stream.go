@@ -0,0 +1,141 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// maxStreamLineSize is the maximum size of a single line in a NDJSON or
+	// server-sent events stream.
+	maxStreamLineSize = 1024 * 1024
+)
+
+// StreamBuildLogs requests the build logs of the given deployment in NDJSON
+// format and calls fn with each log entry as soon as it arrives. It returns
+// once the stream is closed by the server, i.e. the build has completed, or
+// ctx is done.
+//
+// The generated GetBuildLogsWithResponse cannot be used for this purpose,
+// since it buffers the whole response body before parsing it.
+func StreamBuildLogs(ctx context.Context, c ClientInterface, deploymentId string, fn func(BuildLogsResponseEntry)) error {
+	resp, err := c.GetBuildLogs(ctx, deploymentId, func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", "application/x-ndjson")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := checkStreamResponse(resp); err != nil {
+		return err
+	}
+
+	err = decodeStream(resp.Body, resp.Header.Get("Content-Type"), func(entry BuildLogsResponseEntry) error {
+		fn(entry)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decode build logs: %w", err)
+	}
+	return nil
+}
+
+// decodeStream decodes the entries in body and calls fn with each of them as
+// soon as it arrives. Depending on contentType, body is either a stream of
+// server-sent events whose data are JSON objects, a JSON array when the server
+// doesn't support streaming, or NDJSON otherwise. Decoding stops at the first
+// error returned by fn.
+func decodeStream[T any](body io.Reader, contentType string, fn func(T) error) error {
+	if strings.Contains(contentType, "text/event-stream") {
+		return decodeEventStream(body, fn)
+	}
+
+	// The server may not support streaming and return all the entries at once.
+	if strings.Contains(contentType, "json") && !strings.Contains(contentType, "ndjson") {
+		var entries []T
+		if err := json.NewDecoder(body).Decode(&entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	scanner := newLineScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry T
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return fmt.Errorf("failed to decode line %q: %w", line, err)
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// decodeEventStream decodes a stream of server-sent events, calling fn with
+// the data of each event decoded as JSON. Fields other than data, as well as
+// comments sent to keep the connection alive, are ignored.
+func decodeEventStream[T any](body io.Reader, fn func(T) error) error {
+	var data []string
+	dispatch := func() error {
+		if len(data) == 0 {
+			return nil
+		}
+		payload := strings.Join(data, "\n")
+		data = data[:0]
+
+		var entry T
+		if err := json.Unmarshal([]byte(payload), &entry); err != nil {
+			return fmt.Errorf("failed to decode event %q: %w", payload, err)
+		}
+		return fn(entry)
+	}
+
+	scanner := newLineScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// A blank line terminates the event
+			if err := dispatch(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, ":"):
+			continue
+		default:
+			field, value, _ := strings.Cut(line, ":")
+			if field == "data" {
+				data = append(data, strings.TrimPrefix(value, " "))
+			}
+		}
+	}
+
+	// An event that is not terminated by a blank line is incomplete and
+	// discarded, as specified by the HTML standard.
+	return scanner.Err()
+}
+
+// newLineScanner returns a bufio.Scanner reading the lines of body, each of
+// which may be up to maxStreamLineSize long.
+func newLineScanner(body io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+	return scanner
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStreamBuildLogs(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    []BuildLogsResponseEntry
+	}{
+		{
+			name:        "ndjson",
+			contentType: "application/x-ndjson",
+			body:        "{\"level\":\"info\",\"message\":\"Downloading\"}\n\n{\"level\":\"error\",\"message\":\"Failed\"}\n",
+			expected: []BuildLogsResponseEntry{
+				{Level: "info", Message: "Downloading"},
+				{Level: "error", Message: "Failed"},
+			},
+		},
+		{
+			name:        "server-sent events",
+			contentType: "text/event-stream",
+			body:        ": keep-alive\n\nevent: log\ndata: {\"level\":\"info\",\ndata: \"message\":\"Downloading\"}\n\ndata: {\"level\":\"info\",\"message\":\"Incomplete\"}\n",
+			expected: []BuildLogsResponseEntry{
+				{Level: "info", Message: "Downloading"},
+			},
+		},
+		{
+			name:        "json array",
+			contentType: "application/json",
+			body:        `[{"level":"info","message":"Downloading"}]`,
+			expected: []BuildLogsResponseEntry{
+				{Level: "info", Message: "Downloading"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/deployments/abc/build_logs" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				if got := r.Header.Get("Accept"); got != "application/x-ndjson" {
+					t.Errorf("unexpected Accept header: %s", got)
+				}
+				w.Header().Set("Content-Type", tt.contentType)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []BuildLogsResponseEntry
+			err = StreamBuildLogs(context.Background(), c, "abc", func(entry BuildLogsResponseEntry) {
+				got = append(got, entry)
+			})
+			if err != nil {
+				t.Fatalf("StreamBuildLogs() returned error: %s", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("StreamBuildLogs() got %d entries, want %d", len(got), len(tt.expected))
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("StreamBuildLogs() entry %d = %v, want %v", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
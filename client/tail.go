@@ -0,0 +1,293 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DEFAULT_MAX_RECONNECTS is the default number of consecutive attempts to
+	// reconnect to the app log stream without receiving any log.
+	DEFAULT_MAX_RECONNECTS = 5
+	// DEFAULT_RECONNECT_WAIT is the default wait before the first attempt to
+	// reconnect, which is doubled for every consecutive attempt.
+	DEFAULT_RECONNECT_WAIT = 1 * time.Second
+)
+
+// TailConfig configures the behavior of TailAppLogs.
+type TailConfig struct {
+	// Q, Level and Region filter the logs the same way as the parameters of
+	// GetAppLogs.
+	Q      *string
+	Level  *LogLevel
+	Region *Region
+	// MaxReconnects is the maximum number of consecutive attempts to
+	// reconnect without receiving any log. Zero means DEFAULT_MAX_RECONNECTS,
+	// and a negative value disables reconnecting.
+	MaxReconnects int
+	// ReconnectWait is the wait before the first attempt to reconnect. It is
+	// doubled for every consecutive attempt, up to DEFAULT_MAX_RETRY_WAIT.
+	// Zero means DEFAULT_RECONNECT_WAIT.
+	ReconnectWait time.Duration
+}
+
+// AppLogsTail is a real-time stream of the app logs of a deployment, started
+// by TailAppLogs.
+//
+// Use it like this:
+//
+//	tail := client.TailAppLogs(ctx, c, deploymentId, client.TailConfig{})
+//	for entry := range tail.Entries() {
+//		...
+//	}
+//	if err := tail.Err(); err != nil {
+//		...
+//	}
+type AppLogsTail struct {
+	entries chan AppLogsResponseEntry
+	err     error
+
+	// last is the time of the latest log sent to entries, and seen holds
+	// the logs sent with exactly that time, so that the logs replayed after
+	// reconnecting are not sent twice.
+	last time.Time
+	seen map[AppLogsResponseEntry]bool
+	// connectedAt is the time the first stream was requested, from which the
+	// logs are caught up if the stream drops before any log arrives.
+	connectedAt time.Time
+}
+
+// TailAppLogs requests the app logs of the given deployment in real time and
+// sends each log entry to the channel returned by Entries as soon as it
+// arrives. The stream is decoded incrementally, whether it is NDJSON or
+// server-sent events.
+//
+// When the stream drops, TailAppLogs reconnects with backoff, first fetching
+// the logs since the time of the latest received log, or since the first
+// stream connected if no log has been received yet, so that none is missed
+// in between. It stops when ctx is done, when the API responds with an error
+// that retrying won't fix, such as 401 or 404, or after config.MaxReconnects
+// consecutive attempts without receiving any log.
+//
+// The entries are sent only as fast as the caller receives them. A caller that
+// stops reading from Entries before the channel is closed must cancel ctx,
+// otherwise the goroutine following the stream is never released.
+//
+// The generated GetAppLogsWithResponse cannot be used for this purpose,
+// since it buffers the whole response body before parsing it, which never
+// completes for a real-time stream.
+func TailAppLogs(ctx context.Context, c ClientInterface, deploymentId string, config TailConfig) *AppLogsTail {
+	t := &AppLogsTail{
+		entries: make(chan AppLogsResponseEntry),
+		seen:    map[AppLogsResponseEntry]bool{},
+	}
+	go func() {
+		defer close(t.entries)
+		t.err = t.run(ctx, c, deploymentId, config)
+	}()
+	return t
+}
+
+// Entries returns the channel of the log entries. It is closed once the tail
+// stops.
+func (t *AppLogsTail) Entries() <-chan AppLogsResponseEntry {
+	return t.entries
+}
+
+// Err returns the error that stopped the tail, which is ctx.Err() if ctx is
+// done. It must only be called after the channel returned by Entries is
+// closed.
+func (t *AppLogsTail) Err() error {
+	return t.err
+}
+
+func (t *AppLogsTail) run(ctx context.Context, c ClientInterface, deploymentId string, config TailConfig) error {
+	maxReconnects := config.MaxReconnects
+	if maxReconnects == 0 {
+		maxReconnects = DEFAULT_MAX_RECONNECTS
+	}
+	reconnectWait := config.ReconnectWait
+	if reconnectWait == 0 {
+		reconnectWait = DEFAULT_RECONNECT_WAIT
+	}
+
+	failures := 0
+	for {
+		received, err := t.follow(ctx, c, deploymentId, config)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && !isTransientStatus(apiErr.StatusCode) {
+			return err
+		}
+
+		if received {
+			failures = 0
+		}
+		failures++
+		if failures > maxReconnects {
+			if err == nil {
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("app log stream of deployment %s dropped %d times in a row: %w", deploymentId, failures, err)
+		}
+
+		wait := DEFAULT_MAX_RETRY_WAIT
+		// Guard against overflow for large attempts
+		if failures < 32 {
+			wait = min(reconnectWait<<(failures-1), DEFAULT_MAX_RETRY_WAIT)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// follow catches up with the logs since the latest received one, or since
+// the first stream connected, if any, then follows the real-time stream until
+// it drops. It returns whether any log was sent to entries.
+func (t *AppLogsTail) follow(ctx context.Context, c ClientInterface, deploymentId string, config TailConfig) (bool, error) {
+	received := false
+	emit := func(entry AppLogsResponseEntry) error {
+		if !t.accept(entry) {
+			return nil
+		}
+		select {
+		case t.entries <- entry:
+			received = true
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	since := t.last
+	if since.IsZero() {
+		since = t.connectedAt
+	}
+	if !since.IsZero() {
+		if err := t.catchUp(ctx, c, deploymentId, config, since, emit); err != nil {
+			return received, err
+		}
+	}
+
+	// Without a time range, the API streams the logs in real time
+	params := &GetAppLogsParams{
+		Q:      config.Q,
+		Level:  config.Level,
+		Region: config.Region,
+	}
+	requestedAt := time.Now()
+	resp, err := c.GetAppLogs(ctx, deploymentId, params, func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Accept", "application/x-ndjson, text/event-stream")
+		return nil
+	})
+	if err != nil {
+		return received, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := checkStreamResponse(resp); err != nil {
+		return received, err
+	}
+	if t.connectedAt.IsZero() {
+		t.connectedAt = requestedAt
+	}
+
+	if err := decodeStream(resp.Body, resp.Header.Get("Content-Type"), emit); err != nil {
+		return received, fmt.Errorf("failed to decode app logs: %w", err)
+	}
+	return received, nil
+}
+
+// catchUp sends the logs from since up to now, following the cursor of every
+// page.
+func (t *AppLogsTail) catchUp(ctx context.Context, c ClientInterface, deploymentId string, config TailConfig, since time.Time, emit func(AppLogsResponseEntry) error) error {
+	until := time.Now()
+	order := TimeAsc
+	limit := MAX_PAGE_SIZE
+	params := &GetAppLogsParams{
+		Q:      config.Q,
+		Level:  config.Level,
+		Region: config.Region,
+		Since:  &since,
+		Until:  &until,
+		Order:  &order,
+		Limit:  &limit,
+	}
+
+	for {
+		resp, err := c.GetAppLogs(ctx, deploymentId, params)
+		if err != nil {
+			return err
+		}
+		err = func() error {
+			defer func() { _ = resp.Body.Close() }()
+			if err := checkStreamResponse(resp); err != nil {
+				return err
+			}
+			if err := decodeStream(resp.Body, resp.Header.Get("Content-Type"), emit); err != nil {
+				return fmt.Errorf("failed to decode app logs: %w", err)
+			}
+			return nil
+		}()
+		if err != nil {
+			return err
+		}
+
+		next, ok := ParseLinkHeader(resp.Header.Get("Link"))["next"]
+		if !ok {
+			return nil
+		}
+		cursor := next.Query().Get("cursor")
+		if cursor == "" {
+			return nil
+		}
+		params.Cursor = &cursor
+	}
+}
+
+// accept records the entry as received and returns true, unless it is older
+// than the latest received log or has already been received.
+func (t *AppLogsTail) accept(entry AppLogsResponseEntry) bool {
+	// Normalize the time so that the same log decoded from different
+	// responses is recognized
+	key := entry
+	key.Time = key.Time.UTC()
+
+	switch {
+	case entry.Time.Before(t.last):
+		return false
+	case entry.Time.Equal(t.last):
+		if t.seen[key] {
+			return false
+		}
+	default:
+		t.last = entry.Time
+		clear(t.seen)
+	}
+	t.seen[key] = true
+	return true
+}
+
+// checkStreamResponse returns an *APIError if the status code of the streamed
+// response is >= 400, and nil otherwise.
+func checkStreamResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return NewAPIError(resp, body)
+}
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTailAppLogs(t *testing.T) {
+	base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	entries := []AppLogsResponseEntry{
+		{Time: base, Level: Info, Region: Region("gcp-us-east4"), Message: "first"},
+		{Time: base.Add(time.Second), Level: Info, Region: Region("gcp-us-east4"), Message: "second"},
+		{Time: base.Add(time.Second), Level: Error, Region: Region("gcp-us-east4"), Message: "second, again"},
+		{Time: base.Add(2 * time.Second), Level: Warning, Region: Region("gcp-asia-northeast1"), Message: "third"},
+	}
+	encode := func(entry AppLogsResponseEntry) string {
+		b, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/deployments/abc/app_logs" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if got := query.Get("q"); got != "foo" {
+			t.Errorf("unexpected q: %s", got)
+		}
+
+		switch requests.Add(1) {
+		case 1:
+			// The stream drops after the second log
+			if query.Has("since") || query.Has("until") {
+				t.Errorf("unexpected time range in the stream request: %s", r.URL.RawQuery)
+			}
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			fmt.Fprintf(w, "%s\n%s\n", encode(entries[0]), encode(entries[1]))
+		case 2:
+			// Catching up with the logs since the second one, which is
+			// included again
+			since, err := time.Parse(time.RFC3339, query.Get("since"))
+			if err != nil || !since.Equal(entries[1].Time) {
+				t.Errorf("unexpected since: %s", query.Get("since"))
+			}
+			if !query.Has("until") {
+				t.Errorf("until is missing in the catch-up request: %s", r.URL.RawQuery)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, "[%s,%s]", encode(entries[1]), encode(entries[2]))
+		case 3:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, ": keep-alive\n\ndata: %s\n\ndata: %s\n\n", encode(entries[2]), encode(entries[3]))
+		default:
+			// Hang until the client goes away
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	q := "foo"
+	tail := TailAppLogs(ctx, c, "abc", TailConfig{Q: &q, ReconnectWait: time.Millisecond})
+
+	var got []AppLogsResponseEntry
+	for entry := range tail.Entries() {
+		got = append(got, entry)
+		if len(got) == len(entries) {
+			cancel()
+		}
+	}
+
+	if err := tail.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want context.Canceled", err)
+	}
+	if len(got) != len(entries) {
+		t.Fatalf("got %d entries, want %d: %v", len(got), len(entries), got)
+	}
+	for i := range got {
+		if !got[i].Time.Equal(entries[i].Time) || got[i].Message != entries[i].Message {
+			t.Errorf("entry %d = %v, want %v", i, got[i], entries[i])
+		}
+	}
+}
+
+func TestTailAppLogsDropsBeforeAnyLog(t *testing.T) {
+	entry := AppLogsResponseEntry{Time: time.Now().UTC(), Level: Info, Region: Region("gcp-us-east4"), Message: "missed"}
+
+	var requests atomic.Int32
+	var firstConnected time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch requests.Add(1) {
+		case 1:
+			// The stream drops before any log arrives
+			firstConnected = time.Now()
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		case 2:
+			// Catching up with the logs since the first stream connected
+			since, err := time.Parse(time.RFC3339, query.Get("since"))
+			if err != nil || since.After(firstConnected) {
+				t.Errorf("unexpected since: %s, want no later than %s", query.Get("since"), firstConnected)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			b, err := json.Marshal([]AppLogsResponseEntry{entry})
+			if err != nil {
+				t.Error(err)
+			}
+			_, _ = w.Write(b)
+		default:
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.WriteHeader(http.StatusOK)
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tail := TailAppLogs(ctx, c, "abc", TailConfig{ReconnectWait: time.Millisecond})
+	var got []AppLogsResponseEntry
+	for e := range tail.Entries() {
+		got = append(got, e)
+		cancel()
+	}
+
+	if len(got) != 1 || got[0].Message != entry.Message {
+		t.Errorf("got %v, want the log caught up after the first stream dropped", got)
+	}
+}
+
+func TestTailAppLogsStops(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxReconnects    int
+		handler          http.HandlerFunc
+		expectedRequests int32
+		check            func(error) bool
+	}{
+		{
+			name:          "not found",
+			maxReconnects: 3,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"code":"deploymentNotFound","message":"The requested deployment was not found."}`)
+			},
+			expectedRequests: 1,
+			check:            IsNotFound,
+		},
+		{
+			name:          "gives up reconnecting",
+			maxReconnects: 2,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+			},
+			// Each reconnection catches up with the logs since the first
+			// stream connected before following the stream again
+			expectedRequests: 5,
+			check: func(err error) bool {
+				return err != nil && !errors.Is(err, context.DeadlineExceeded)
+			},
+		},
+		{
+			name:          "reconnecting disabled",
+			maxReconnects: -1,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			expectedRequests: 1,
+			check: func(err error) bool {
+				return hasStatusCode(err, http.StatusServiceUnavailable)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+				tt.handler(w, r)
+			}))
+			defer server.Close()
+
+			c, err := NewClient(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			tail := TailAppLogs(ctx, c, "abc", TailConfig{MaxReconnects: tt.maxReconnects, ReconnectWait: time.Millisecond})
+			for entry := range tail.Entries() {
+				t.Errorf("unexpected entry: %v", entry)
+			}
+
+			if err := tail.Err(); !tt.check(err) {
+				t.Errorf("unexpected Err(): %v", err)
+			}
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("got %d requests, want %d", got, tt.expectedRequests)
+			}
+		})
+	}
+}
@@ -1,37 +1,27 @@
 package client
 
 import (
-	"fmt"
 	"net/http"
 )
 
+//go:generate go run ./internal/genresponses -in client.go -out response.gen.go
+
 const (
 	X_DENO_RAY = "x-deno-ray"
 )
 
+// APIResponse is implemented by every response type of the generated client.
+// The methods other than StatusCode are generated into response.gen.go.
 type APIResponse interface {
+	// StatusCode returns the HTTP status code of the response.
 	StatusCode() int
-}
-
-// RespIsError returns true if the response status code is >= 400.
-func RespIsError(resp APIResponse) bool {
-	return resp.StatusCode() >= 400
-}
-
-// APIErrorDetail returns a string with the error details from the API response.
-// TODO: We could make the parameter simpler by modifying a template used for
-// API client generation to add `GetBody` and `GetHeaders` methods to every
-// response structs.
-// See https://github.com/deepmap/oapi-codegen/issues/240
-func APIErrorDetail(resp *http.Response, body []byte) string {
-	if resp == nil {
-		return "failed to extract API error detail"
-	}
-
-	traceID := resp.Header.Get(X_DENO_RAY)
-	if traceID == "" {
-		traceID = "<unknown>"
-	}
-
-	return fmt.Sprintf("API request errored with status code %d.\nResponse body: %s\n\nPlease contact the support team with the ID: %s.", resp.StatusCode, body, traceID)
+	// GetBody returns the raw response body.
+	GetBody() []byte
+	// GetHTTPResponse returns the underlying HTTP response.
+	GetHTTPResponse() *http.Response
+	// GetHeaders returns the response headers.
+	GetHeaders() http.Header
+	// TraceID returns the value of the x-deno-ray response header, which the
+	// support team can use to look into the request.
+	TraceID() string
 }
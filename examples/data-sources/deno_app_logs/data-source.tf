@@ -0,0 +1,16 @@
+# Smoke check: fail if any error logs were emitted in the first 5 minutes
+# after the deployment was created
+data "deno_app_logs" "errors" {
+  deployment_id = deno_deployment.example.deployment_id
+  since         = deno_deployment.example.created_at
+  until         = timeadd(deno_deployment.example.created_at, "5m")
+  levels        = ["error"]
+  limit         = 10
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.logs) == 0
+      error_message = "The deployment emitted error logs: ${join("\n", self.logs[*].message)}"
+    }
+  }
+}
@@ -0,0 +1,16 @@
+# Refer to a deployment created outside of Terraform, e.g. by deployctl
+data "deno_deployment" "example" {
+  deployment_id = "abcd1234efgh"
+
+  # Fetch the build logs into `build_logs`
+  include_build_logs = true
+}
+
+resource "deno_domain_association" "example" {
+  domain_id     = deno_domain.example.id
+  deployment_id = data.deno_deployment.example.deployment_id
+}
+
+output "build_errors" {
+  value = [for log in data.deno_deployment.example.build_logs : log.message if log.level == "error"]
+}
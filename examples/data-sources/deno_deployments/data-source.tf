@@ -0,0 +1,15 @@
+# Look up the latest successful deployment of a project
+data "deno_deployments" "latest" {
+  project_id  = deno_project.example.id
+  status      = "success"
+  most_recent = true
+}
+
+resource "deno_domain_association" "example" {
+  domain_id     = deno_domain.example.id
+  deployment_id = data.deno_deployments.latest.deployments[0].deployment_id
+}
+
+output "latest_deployment_domains" {
+  value = data.deno_deployments.latest.deployments[0].domains
+}
@@ -0,0 +1,15 @@
+# Look up a domain managed by another team, and add its DNS records
+data "deno_domain" "example" {
+  domain = "foo.example.com"
+}
+
+resource "cloudflare_record" "deno" {
+  count = length(data.deno_domain.example.dns_records)
+
+  zone_id = var.cloudflare_zone_id
+  type    = upper(data.deno_domain.example.dns_records[count.index].type)
+  name    = data.deno_domain.example.dns_records[count.index].name
+  value   = data.deno_domain.example.dns_records[count.index].content
+  proxied = false
+  ttl     = 120
+}
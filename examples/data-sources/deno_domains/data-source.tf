@@ -0,0 +1,11 @@
+data "deno_domains" "all" {}
+
+# The domains whose ownership has not been verified yet
+output "unverified_domains" {
+  value = [for d in data.deno_domains.all.domains : d.domain if !d.is_validated]
+}
+
+# The expiry of the certificates of each domain
+output "certificate_expiry" {
+  value = { for d in data.deno_domains.all.domains : d.domain => [for c in d.certificates : c.expires_at] }
+}
@@ -0,0 +1,5 @@
+data "deno_organization" "current" {}
+
+output "organization_name" {
+  value = data.deno_organization.current.name
+}
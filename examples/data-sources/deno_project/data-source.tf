@@ -0,0 +1,14 @@
+# Look up a project by its name, e.g. one managed in another workspace
+data "deno_project" "by_name" {
+  name = "my-project"
+}
+
+# Look up a project by its ID
+data "deno_project" "by_id" {
+  id = "00000000-0000-0000-0000-000000000000"
+}
+
+resource "deno_deployment" "example" {
+  project_id = data.deno_project.by_name.id
+  # ...
+}
@@ -0,0 +1,15 @@
+data "deno_project_analytics" "example" {
+  project_id = deno_project.example.id
+}
+
+output "total_requests" {
+  value = data.deno_project_analytics.example.aggregates["numRequests"].sum
+}
+
+# Pair the time of each row with the number of requests
+output "requests_over_time" {
+  value = zipmap(
+    one([for f in data.deno_project_analytics.example.fields : f.time_values if f.name == "time"]),
+    one([for f in data.deno_project_analytics.example.fields : f.number_values if f.name == "numRequests"]),
+  )
+}
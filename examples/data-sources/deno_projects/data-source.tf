@@ -0,0 +1,14 @@
+# List the projects whose names start with "staging-"
+data "deno_projects" "staging" {
+  name_prefix = "staging-"
+}
+
+# List the projects created in 2024
+data "deno_projects" "created_in_2024" {
+  created_after  = "2024-01-01T00:00:00Z"
+  created_before = "2025-01-01T00:00:00Z"
+}
+
+output "staging_project_ids" {
+  value = { for p in data.deno_projects.staging.projects : p.name => p.id }
+}
@@ -0,0 +1,3 @@
+# A deployment can be imported by its ID. The assets and environment variables
+# can't be imported, so the next apply creates a new deployment from the configuration.
+terraform import deno_deployment.example abcdefghijkl
@@ -28,12 +28,17 @@
 resource "deno_project" "my_project" {}
 
 data "deno_assets" "my_assets" {
-  glob = "../**/*.{ts,tsx,json,ico,svg,css}"
+  # The app is located in the parent directory of terraform/.
+  root_dir = ".."
+  glob     = "**/*.{ts,tsx,json,ico,svg,css}"
 }
 
 resource "deno_deployment" "example1" {
   # Project ID that the created deployment belongs to.
   project_id = deno_project.myproject.id
+  # Upload the assets rooted at the app directory, i.e. `../main.ts` is
+  # deployed as `main.ts`.
+  root_dir = ".."
   # File path for the deployments' entry point.
   entry_point_url = "../main.ts"
   compiler_options = {
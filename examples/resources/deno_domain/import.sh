@@ -0,0 +1,5 @@
+# A domain can be imported by its ID.
+terraform import deno_domain.example 00000000-0000-0000-0000-000000000000
+
+# Or by the domain name.
+terraform import deno_domain.example foo.example.com
@@ -66,3 +66,11 @@ resource "deno_domain_certificate" "example" {
 
   domain_id = deno_domain.example.id
 }
+
+# Point the domain at a deployment.
+resource "deno_domain_association" "example" {
+  depends_on = [deno_domain_certificate.example]
+
+  domain_id     = deno_domain.example.id
+  deployment_id = deno_deployment.example.deployment_id
+}
@@ -0,0 +1,12 @@
+# This resource is intended to be used with other resources to get the custom domain all set up.
+# For full example, see the doc of `deno_domain`.
+
+resource "deno_domain_association" "example" {
+  # The certificate must be ready to associate the domain with a deployment.
+  depends_on = [deno_domain_certificate.example]
+
+  # The domain to associate.
+  domain_id = deno_domain.example.id
+  # The deployment that the domain points to.
+  deployment_id = deno_deployment.example.deployment_id
+}
@@ -0,0 +1,2 @@
+# The certificate provisioning can be imported by the ID of the domain.
+terraform import deno_domain_certificate.example 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,14 @@
+# This resource is an alternative to `deno_domain_certificate` for certificates
+# issued by your own certificate authority.
+# For full example of the custom domain setup, see the doc of `deno_domain`.
+
+resource "deno_domain_custom_certificate" "example" {
+  # Domain ownership verification must be completed to add a certificate.
+  depends_on = [deno_domain_verification.example]
+
+  # The domain to upload a certificate for.
+  domain_id = deno_domain.example.id
+  # The leaf certificate first, followed by the intermediate certificates.
+  certificate_chain = file("${path.module}/certs/fullchain.pem")
+  private_key       = file("${path.module}/certs/privkey.pem")
+}
@@ -0,0 +1,390 @@
+// Package cassette records HTTP exchanges into files, called cassettes, and
+// replays them later, so that tests built from real API traffic can run
+// deterministically without network.
+//
+// Both Recorder and Player implement client.HttpRequestDoer. Secrets are
+// scrubbed before a cassette is written: request headers, including
+// Authorization, are never recorded, the values of environment variables in
+// deployment requests are redacted, and every occurrence of the given secret
+// values is replaced with a placeholder. The player puts the current values
+// back in place of the placeholders.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"terraform-provider-deno/client"
+)
+
+const (
+	// redacted replaces the values of environment variables in recorded
+	// request bodies.
+	redacted = "REDACTED"
+)
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a pair of a request and the response to it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   Body   `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is a recorded HTTP body. It is written as a string if it's valid
+// UTF-8, and as a base64-encoded object otherwise.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+// scrubber replaces secret values with placeholders and vice versa.
+type scrubber struct {
+	// names are the placeholder names of the secret values, sorted so that
+	// the longest value is replaced first.
+	names  []string
+	values []string
+}
+
+func newScrubber(secrets map[string]string) scrubber {
+	s := scrubber{}
+	for name, value := range secrets {
+		if value == "" {
+			continue
+		}
+		s.names = append(s.names, name)
+		s.values = append(s.values, value)
+	}
+	sort.Sort(byValueLength(s))
+	return s
+}
+
+type byValueLength scrubber
+
+func (s byValueLength) Len() int           { return len(s.names) }
+func (s byValueLength) Less(i, j int) bool { return len(s.values[i]) > len(s.values[j]) }
+func (s byValueLength) Swap(i, j int) {
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+}
+
+func placeholder(name string) string {
+	return fmt.Sprintf("{{%s}}", name)
+}
+
+func (s scrubber) scrub(v string) string {
+	for i, value := range s.values {
+		v = strings.ReplaceAll(v, value, placeholder(s.names[i]))
+	}
+	return v
+}
+
+func (s scrubber) unscrub(v string) string {
+	for i, name := range s.names {
+		v = strings.ReplaceAll(v, placeholder(name), s.values[i])
+	}
+	return v
+}
+
+func (s scrubber) scrubBody(b []byte) []byte {
+	if !utf8.Valid(b) {
+		return b
+	}
+	return []byte(s.scrub(string(b)))
+}
+
+func (s scrubber) unscrubBody(b []byte) []byte {
+	if !utf8.Valid(b) {
+		return b
+	}
+	return []byte(s.unscrub(string(b)))
+}
+
+func (s scrubber) scrubHeader(h http.Header) http.Header {
+	ret := http.Header{}
+	for key, values := range h {
+		for _, value := range values {
+			ret.Add(key, s.scrub(value))
+		}
+	}
+	return ret
+}
+
+func (s scrubber) unscrubHeader(h http.Header) http.Header {
+	ret := http.Header{}
+	for key, values := range h {
+		for _, value := range values {
+			ret.Add(key, s.unscrub(value))
+		}
+	}
+	return ret
+}
+
+// redactEnvVars replaces the values of the envVars field in a JSON request
+// body, i.e. the environment variables of a deployment, with a fixed string.
+func redactEnvVars(body []byte) []byte {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body
+	}
+	rawEnvVars, ok := fields["envVars"]
+	if !ok {
+		return body
+	}
+	var envVars map[string]string
+	if err := json.Unmarshal(rawEnvVars, &envVars); err != nil {
+		return body
+	}
+
+	for key := range envVars {
+		envVars[key] = redacted
+	}
+	redactedEnvVars, err := json.Marshal(envVars)
+	if err != nil {
+		return body
+	}
+	fields["envVars"] = redactedEnvVars
+	ret, err := json.Marshal(fields)
+	if err != nil {
+		return body
+	}
+	return ret
+}
+
+// Recorder is a client.HttpRequestDoer that sends requests through another
+// doer, and records the exchanges.
+type Recorder struct {
+	doer     client.HttpRequestDoer
+	path     string
+	scrubber scrubber
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder which sends requests through doer, and saves
+// the exchanges to the cassette file at path. secrets maps placeholder names
+// to the values to scrub, such as the access token.
+func NewRecorder(path string, doer client.HttpRequestDoer, secrets map[string]string) *Recorder {
+	return &Recorder{
+		doer:     doer,
+		path:     path,
+		scrubber: newScrubber(secrets),
+	}
+}
+
+// Do sends the request and records the exchange. The response body is
+// recorded as the caller reads it, so that streamed responses, such as build
+// logs, are passed through as they arrive. Only the part of the body read
+// before it is closed ends up in the cassette.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, err := r.doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.scrubber.scrub(req.URL.String()),
+			Body:   r.scrubber.scrubBody(redactEnvVars(reqBody)),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrubber.scrubHeader(resp.Header),
+		},
+	})
+	resp.Body = &recordingBody{
+		ReadCloser: resp.Body,
+		recorder:   r,
+		index:      len(r.cassette.Interactions) - 1,
+	}
+
+	return resp, nil
+}
+
+// recordingBody is a response body which appends what is read from it to the
+// recorded response. The body is kept unscrubbed until the cassette is saved,
+// since a secret may be split across reads.
+type recordingBody struct {
+	io.ReadCloser
+	recorder *Recorder
+	index    int
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.recorder.mu.Lock()
+		defer b.recorder.mu.Unlock()
+		response := &b.recorder.cassette.Interactions[b.index].Response
+		response.Body = append(response.Body, p[:n]...)
+	}
+	return n, err
+}
+
+// Save writes the recorded exchanges to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette := Cassette{Interactions: make([]Interaction, len(r.cassette.Interactions))}
+	for i, interaction := range r.cassette.Interactions {
+		interaction.Response.Body = r.scrubber.scrubBody(interaction.Response.Body)
+		cassette.Interactions[i] = interaction
+	}
+
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// Player is a client.HttpRequestDoer that responds to requests with the
+// exchanges recorded in a cassette, without sending them anywhere.
+//
+// A request is matched with the first interaction that has not been replayed
+// yet and has the same method and URL. Request bodies are not compared, since
+// they may contain redacted values.
+type Player struct {
+	scrubber scrubber
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// Load reads the cassette file at path and returns a Player replaying it.
+// secrets maps the placeholder names to the values to put back into the
+// responses.
+func Load(path string, secrets map[string]string) (*Player, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+	}
+
+	return &Player{
+		scrubber:     newScrubber(secrets),
+		interactions: cassette.Interactions,
+		replayed:     make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// Do responds to the request with the matching recorded response.
+func (p *Player) Do(req *http.Request) (*http.Response, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	url := p.scrubber.scrub(req.URL.String())
+	for i, interaction := range p.interactions {
+		if p.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		p.replayed[i] = true
+
+		body := p.scrubber.unscrubBody(interaction.Response.Body)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        p.scrubber.unscrubHeader(interaction.Response.Header),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction left for %s %s", req.Method, url)
+}
+
+// Remaining returns the number of interactions that have not been replayed.
+func (p *Player) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := 0
+	for _, replayed := range p.replayed {
+		if !replayed {
+			n++
+		}
+	}
+	return n
+}
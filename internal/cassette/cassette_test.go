@@ -0,0 +1,372 @@
+package cassette
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-deno/client"
+	"terraform-provider-deno/internal/fakedeploy"
+
+	"github.com/google/uuid"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	const (
+		token = "ddp_secret_token"
+		orgID = "a1b2c3d4-0000-0000-0000-000000000000"
+	)
+
+	binary := []byte{0xff, 0xfe, 0x00, 0x01}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/organizations/" + orgID:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"id":"`+orgID+`"}`)
+		case "/binary":
+			_, _ = w.Write(binary)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+	recordSecrets := map[string]string{
+		"HOST":   server.URL,
+		"TOKEN":  token,
+		"ORG_ID": orgID,
+	}
+
+	recorder := NewRecorder(path, http.DefaultClient, recordSecrets)
+	send := func(doer interface {
+		Do(*http.Request) (*http.Response, error)
+	}, host, method, path string, body string) (int, []byte) {
+		t.Helper()
+		req, err := http.NewRequest(method, host+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := doer.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, respBody
+	}
+
+	send(recorder, server.URL, http.MethodGet, "/organizations/"+orgID, "")
+	send(recorder, server.URL, http.MethodPost, "/binary", `{"envVars":{"SECRET":"hunter2"}}`)
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Secrets are scrubbed from the cassette
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{token, orgID, server.URL, "hunter2", "Authorization"} {
+		if bytes.Contains(saved, []byte(secret)) {
+			t.Errorf("Expected %s to be scrubbed from the cassette:\n%s", secret, saved)
+		}
+	}
+
+	// Replay with different values, without the server
+	replayHost := "https://replay.example.com"
+	replayOrgID := "ffffffff-0000-0000-0000-000000000000"
+	player, err := Load(path, map[string]string{
+		"HOST":   replayHost,
+		"TOKEN":  "other_token",
+		"ORG_ID": replayOrgID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, body := send(player, replayHost, http.MethodGet, "/organizations/"+replayOrgID, "")
+	if status != http.StatusOK || string(body) != `{"id":"`+replayOrgID+`"}` {
+		t.Errorf("Unexpected replayed response: %d %s", status, body)
+	}
+	status, body = send(player, replayHost, http.MethodPost, "/binary", "")
+	if status != http.StatusOK || !bytes.Equal(body, binary) {
+		t.Errorf("Unexpected replayed response: %d %v", status, body)
+	}
+	if player.Remaining() != 0 {
+		t.Errorf("Expected all interactions to be replayed, %d left", player.Remaining())
+	}
+
+	// Each interaction is replayed only once
+	req, err := http.NewRequest(http.MethodGet, replayHost+"/organizations/"+replayOrgID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := player.Do(req); err == nil {
+		t.Errorf("Expected an error for a request that was not recorded")
+	}
+}
+
+func TestRecordStream(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, "{\"line\":1}\n")
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, "{\"line\":2}\n")
+	}))
+	defer server.Close()
+	defer close(release)
+
+	path := filepath.Join(t.TempDir(), "stream.json")
+	recorder := NewRecorder(path, http.DefaultClient, nil)
+
+	// The first line is available before the server finishes the response
+	done := make(chan *http.Response)
+	go func() {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Error(err)
+			close(done)
+			return
+		}
+		resp, err := recorder.Do(req)
+		if err != nil {
+			t.Error(err)
+			close(done)
+			return
+		}
+		done <- resp
+	}()
+	var resp *http.Response
+	select {
+	case resp = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the response before the stream ends")
+	}
+	if resp == nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	if err != nil || line != "{\"line\":1}\n" {
+		t.Fatalf("Unexpected first line: %q, %v", line, err)
+	}
+
+	release <- struct{}{}
+	rest, err := io.ReadAll(reader)
+	if err != nil || string(rest) != "{\"line\":2}\n" {
+		t.Fatalf("Unexpected rest of the stream: %q, %v", rest, err)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	player, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := player.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replayed.Body.Close()
+	body, err := io.ReadAll(replayed.Body)
+	if err != nil || string(body) != "{\"line\":1}\n{\"line\":2}\n" {
+		t.Errorf("Unexpected replayed stream: %q, %v", body, err)
+	}
+}
+
+func TestRecordAndReplayStreamWithSplitSecret(t *testing.T) {
+	const token = "ddp_secret_token"
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, "{\"token\":\"ddp_sec")
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, "ret_token\"}\n")
+	}))
+	defer server.Close()
+	defer close(release)
+
+	path := filepath.Join(t.TempDir(), "stream.json")
+	recorder := NewRecorder(path, http.DefaultClient, map[string]string{"TOKEN": token})
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := recorder.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The secret arrives in two reads
+	first := make([]byte, 64)
+	n, err := resp.Body.Read(first)
+	if err != nil || string(first[:n]) != "{\"token\":\"ddp_sec" {
+		t.Fatalf("Unexpected first read: %q, %v", first[:n], err)
+	}
+	release <- struct{}{}
+	rest, err := io.ReadAll(resp.Body)
+	if err != nil || string(rest) != "ret_token\"}\n" {
+		t.Fatalf("Unexpected rest of the stream: %q, %v", rest, err)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(saved, []byte("ddp_sec")) {
+		t.Errorf("Expected the secret to be scrubbed from the cassette:\n%s", saved)
+	}
+
+	player, err := Load(path, map[string]string{"TOKEN": "ddp_replay_token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err = http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := player.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replayed.Body.Close()
+	body, err := io.ReadAll(replayed.Body)
+	if err != nil || string(body) != "{\"token\":\"ddp_replay_token\"}\n" {
+		t.Errorf("Unexpected replayed stream: %q, %v", body, err)
+	}
+}
+
+// TestRecordAndReplayDeployment records a deployment against the fake server
+// and replays it with the server closed, the way the acceptance tests do with
+// DENO_DEPLOY_CASSETTE.
+func TestRecordAndReplayDeployment(t *testing.T) {
+	s := fakedeploy.NewServer()
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "deployment.json")
+	recordSecrets := map[string]string{
+		"HOST":   s.URL,
+		"TOKEN":  s.Token,
+		"ORG_ID": s.OrganizationID.String(),
+	}
+	replaySecrets := map[string]string{
+		"HOST":   "https://api.deno.test/v1",
+		"TOKEN":  "ddp_replay_token",
+		"ORG_ID": "00000000-0000-0000-0000-000000000000",
+	}
+
+	// deploy creates a project and deploys an application with two files to
+	// it, returning the build logs and the final status.
+	deploy := func(doer client.HttpRequestDoer, secrets map[string]string) ([]string, client.DeploymentStatus) {
+		t.Helper()
+		ctx := context.Background()
+		c, err := client.NewClientWithResponses(secrets["HOST"], client.WithHTTPClient(doer), client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+secrets["TOKEN"])
+			return nil
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		name := "cassette"
+		project, err := c.CreateProjectWithResponse(ctx, uuid.MustParse(secrets["ORG_ID"]), client.CreateProjectRequest{Name: &name})
+		if err != nil || project.JSON200 == nil {
+			t.Fatalf("Failed to create project: %v", err)
+		}
+
+		assets := client.Assets{}
+		for path, content := range map[string]string{
+			"main.ts":      `import { add } from "./util/calc.ts";`,
+			"util/calc.ts": `export function add(a, b) { return a + b; }`,
+		} {
+			var file client.FileAsset
+			if err := file.FromFileAsset0(client.FileAsset0{Content: content}); err != nil {
+				t.Fatal(err)
+			}
+			file.Kind = client.FileAssetKindFile
+			var asset client.Asset
+			if err := asset.FromFileAsset(file); err != nil {
+				t.Fatal(err)
+			}
+			assets[path] = asset
+		}
+		deployment, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+			EntryPointUrl: "main.ts",
+			Assets:        assets,
+			EnvVars:       map[string]string{"FOO": "secret"},
+		})
+		if err != nil || deployment.JSON200 == nil {
+			t.Fatalf("Failed to create deployment: %v", err)
+		}
+
+		var logs []string
+		if err := client.StreamBuildLogs(ctx, c.ClientInterface, deployment.JSON200.Id, func(entry client.BuildLogsResponseEntry) {
+			logs = append(logs, entry.Message)
+		}); err != nil {
+			t.Fatalf("Failed to stream build logs: %v", err)
+		}
+
+		got, err := c.GetDeploymentWithResponse(ctx, deployment.JSON200.Id)
+		if err != nil || got.JSON200 == nil {
+			t.Fatalf("Failed to get deployment: %v", err)
+		}
+		return logs, got.JSON200.Status
+	}
+
+	recorder := NewRecorder(path, http.DefaultClient, recordSecrets)
+	recordedLogs, recordedStatus := deploy(recorder, recordSecrets)
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if recordedStatus != client.DeploymentStatusSuccess || len(recordedLogs) == 0 {
+		t.Fatalf("Unexpected recorded deployment: %s, %v", recordedStatus, recordedLogs)
+	}
+
+	// Nothing goes to the server while replaying
+	s.Close()
+	player, err := Load(path, replaySecrets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayedLogs, replayedStatus := deploy(player, replaySecrets)
+	if replayedStatus != recordedStatus || !slices.Equal(replayedLogs, recordedLogs) {
+		t.Errorf("Expected the replay to match the recording, got %s, %v", replayedStatus, replayedLogs)
+	}
+	if player.Remaining() != 0 {
+		t.Errorf("Expected all interactions to be replayed, %d left", player.Remaining())
+	}
+}
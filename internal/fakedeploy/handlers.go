@@ -0,0 +1,894 @@
+package fakedeploy
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"terraform-provider-deno/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultPageSize and maxPageSize mirror the limits of the real API.
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// defaultAppLogsLimit is the number of app logs returned in one request
+	// unless the limit is given.
+	defaultAppLogsLimit = 100
+)
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request, rawID string) {
+	if !s.checkOrganization(w, rawID) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.organization)
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request, rawOrganizationID string) {
+	if !s.checkOrganization(w, rawOrganizationID) {
+		return
+	}
+
+	projects := make([]client.Project, 0, len(s.projects))
+	for _, p := range s.projects {
+		projects = append(projects, *p)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].CreatedAt.Before(projects[j].CreatedAt)
+	})
+
+	writePage(w, r, projects)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request, rawOrganizationID string) {
+	if !s.checkOrganization(w, rawOrganizationID) {
+		return
+	}
+
+	var req client.CreateProjectRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+
+	name := "fake-" + randomID(12)
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if s.projectNameTaken(name) {
+		writeError(w, http.StatusConflict, "projectNameInUse", fmt.Sprintf("The project name %s is already in use.", name))
+		return
+	}
+
+	now := time.Now().UTC()
+	project := &client.Project{
+		Id:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.projects[project.Id] = project
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request, rawID string) {
+	project, ok := s.findProject(w, rawID)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request, rawID string) {
+	project, ok := s.findProject(w, rawID)
+	if !ok {
+		return
+	}
+
+	var req client.UpdateProjectRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Name != project.Name && s.projectNameTaken(req.Name) {
+		writeError(w, http.StatusConflict, "projectNameInUse", fmt.Sprintf("The project name %s is already in use.", req.Name))
+		return
+	}
+
+	project.Name = req.Name
+	project.UpdatedAt = time.Now().UTC()
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request, rawID string) {
+	project, ok := s.findProject(w, rawID)
+	if !ok {
+		return
+	}
+
+	delete(s.projects, project.Id)
+	delete(s.analytics, project.Id)
+	delete(s.uploadedHashes, project.Id)
+	for id, d := range s.deployments {
+		if d.ProjectId == project.Id {
+			delete(s.deployments, id)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) getProjectAnalytics(w http.ResponseWriter, r *http.Request, rawID string) {
+	project, ok := s.findProject(w, rawID)
+	if !ok {
+		return
+	}
+
+	analytics, ok := s.analytics[project.Id]
+	if !ok {
+		analytics = client.Analytics{
+			Fields: []client.AnalyticsFieldSchema{},
+			Values: [][]client.AnalyticsDataValue{},
+		}
+	}
+
+	writeJSON(w, http.StatusOK, analytics)
+}
+
+func (s *Server) listDeployments(w http.ResponseWriter, r *http.Request, rawProjectID string) {
+	project, ok := s.findProject(w, rawProjectID)
+	if !ok {
+		return
+	}
+
+	deployments := []client.Deployment{}
+	for _, d := range s.deployments {
+		if d.ProjectId == project.Id {
+			deployments = append(deployments, d.Deployment)
+		}
+	}
+	// The most recent deployment comes first
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].CreatedAt.After(deployments[j].CreatedAt)
+	})
+
+	writePage(w, r, deployments)
+}
+
+func (s *Server) createDeployment(w http.ResponseWriter, r *http.Request, rawProjectID string) {
+	project, ok := s.findProject(w, rawProjectID)
+	if !ok {
+		return
+	}
+
+	var req client.CreateDeploymentRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	received := ReceivedDeployment{Request: req, StatusCode: http.StatusOK}
+	defer func() {
+		s.received[project.Id] = append(s.received[project.Id], received)
+	}()
+
+	// Collect the hashes of the files first, so that nothing is recorded if
+	// the request is rejected
+	uploaded := s.uploadedHashes[project.Id]
+	newHashes := []string{}
+	entryPoint := strings.TrimPrefix(strings.TrimPrefix(req.EntryPointUrl, "file:///"), "./")
+	app := DeployedApp{
+		EntryPoint: entryPoint,
+		Files:      map[string][]byte{},
+		Symlinks:   map[string]string{},
+		EnvVars:    req.EnvVars,
+	}
+	for path, asset := range req.Assets {
+		kind, err := asset.Discriminator()
+		if err != nil {
+			received.StatusCode = http.StatusBadRequest
+			writeError(w, http.StatusBadRequest, "invalidAsset", fmt.Sprintf("Asset %s is invalid: %s", path, err))
+			return
+		}
+		if kind == string(client.SymlinkAssetKindSymlink) {
+			if symlink, err := asset.AsSymlinkAsset(); err == nil {
+				app.Symlinks[path] = symlink.Target
+			}
+			continue
+		}
+		if kind != string(client.FileAssetKindFile) {
+			continue
+		}
+
+		hash, content, err := decodeFileAsset(asset)
+		if err != nil {
+			received.StatusCode = http.StatusBadRequest
+			writeError(w, http.StatusBadRequest, "invalidAsset", fmt.Sprintf("Asset %s is invalid: %s", path, err))
+			return
+		}
+		if content == nil && !uploaded[hash] {
+			received.StatusCode = http.StatusBadRequest
+			writeError(w, http.StatusBadRequest, "assetNotFound", fmt.Sprintf("The content of asset %s with hash %s has never been uploaded.", path, hash))
+			return
+		}
+		newHashes = append(newHashes, hash)
+		if content != nil {
+			s.blobs[hash] = content
+		}
+		app.Files[path] = s.blobs[hash]
+	}
+	if uploaded == nil {
+		uploaded = map[string]bool{}
+		s.uploadedHashes[project.Id] = uploaded
+	}
+	for _, hash := range newHashes {
+		uploaded[hash] = true
+	}
+
+	id := randomID(12)
+	now := time.Now().UTC()
+	d := &deployment{
+		Deployment: client.Deployment{
+			Id:        id,
+			ProjectId: project.Id,
+			Status:    client.DeploymentStatusPending,
+			Domains:   &[]string{fmt.Sprintf("%s-%s.deno.dev", project.Name, id)},
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		finalStatus: client.DeploymentStatusSuccess,
+		builtAt:     now.Add(s.buildDuration),
+		app:         app,
+		buildLogs: []client.BuildLogsResponseEntry{
+			{Level: "info", Message: "Deploying..."},
+		},
+	}
+
+	// Fail the build if the entry point is missing
+	if _, ok := req.Assets[entryPoint]; !ok && !strings.Contains(req.EntryPointUrl, "://") {
+		d.finalStatus = client.DeploymentStatusFailed
+		d.Domains = &[]string{}
+		d.buildLogs = append(d.buildLogs, client.BuildLogsResponseEntry{
+			Level:   "error",
+			Message: fmt.Sprintf("The entry point %s was not found in the assets.", req.EntryPointUrl),
+		})
+	} else {
+		d.buildLogs = append(d.buildLogs, client.BuildLogsResponseEntry{
+			Level:   "info",
+			Message: "Finished deploying.",
+		})
+	}
+	s.deployments[id] = d
+
+	writeJSON(w, http.StatusOK, d.Deployment)
+}
+
+// findDeploymentByHost returns the successful deployment that the host,
+// either one of its domains or a custom domain associated with it, points
+// to, or nil if there is none.
+func (s *Server) findDeploymentByHost(host string) *deployment {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, d := range s.domains {
+		if d.Domain.Domain == host && d.deploymentID != nil {
+			if dep, ok := s.deployments[*d.deploymentID]; ok && dep.Status == client.DeploymentStatusSuccess {
+				return dep
+			}
+		}
+	}
+	for _, d := range s.deployments {
+		if d.Status == client.DeploymentStatusSuccess && slices.Contains(*d.Domains, host) {
+			return d
+		}
+	}
+	return nil
+}
+
+// serveApp responds with what the App registered for the entry point of the
+// deployment returns.
+func (s *Server) serveApp(w http.ResponseWriter, d *deployment) {
+	app, ok := s.apps[d.app.EntryPoint]
+	if !ok {
+		http.Error(w, fmt.Sprintf("No app is registered for the entry point %s.", d.app.EntryPoint), http.StatusBadGateway)
+		return
+	}
+	body, err := app(d.app)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func (s *Server) getDeployment(w http.ResponseWriter, r *http.Request, id string) {
+	d, ok := s.findDeployment(w, id)
+	if !ok {
+		return
+	}
+
+	response := d.Deployment
+	// Report pending on the first read, then complete the build
+	if d.Status == client.DeploymentStatusPending && time.Now().After(d.builtAt) {
+		d.Status = d.finalStatus
+		d.UpdatedAt = time.Now().UTC()
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) getBuildLogs(w http.ResponseWriter, r *http.Request, id string) {
+	d, ok := s.findDeployment(w, id)
+	if !ok {
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept"), "ndjson") {
+		if !time.Now().Before(d.builtAt) {
+			d.Status = d.finalStatus
+		}
+		writeJSON(w, http.StatusOK, d.buildLogs)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+
+	logs := d.buildLogs
+	if wait := time.Until(d.builtAt); wait > 0 {
+		// Stream the first log, then keep the stream open until the build
+		// completes. The lock is released meanwhile so that the other
+		// requests are served.
+		_ = encoder.Encode(logs[0])
+		logs = logs[1:]
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		s.mu.Unlock()
+		select {
+		case <-r.Context().Done():
+		case <-time.After(wait):
+		}
+		s.mu.Lock()
+		if r.Context().Err() != nil {
+			return
+		}
+	}
+
+	// The build completes once its logs are read through
+	d.Status = d.finalStatus
+	for _, entry := range logs {
+		_ = encoder.Encode(entry)
+	}
+}
+
+func (s *Server) getAppLogs(w http.ResponseWriter, r *http.Request, id string) {
+	d, ok := s.findDeployment(w, id)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	since, err := parseTimeParam(query, "since")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidQueryParameter", err.Error())
+		return
+	}
+	until, err := parseTimeParam(query, "until")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidQueryParameter", err.Error())
+		return
+	}
+	levels := splitParam(query, "level")
+	regions := splitParam(query, "region")
+	q := query.Get("q")
+
+	entries := []client.AppLogsResponseEntry{}
+	for _, entry := range d.appLogs {
+		if q != "" && !strings.Contains(entry.Message, q) {
+			continue
+		}
+		if levels != nil && !levels[string(entry.Level)] {
+			continue
+		}
+		if regions != nil && !regions[string(entry.Region)] {
+			continue
+		}
+		if since != nil && entry.Time.Before(*since) {
+			continue
+		}
+		if until != nil && entry.Time.After(*until) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	// Without a time range, the real API streams the logs in real time. The
+	// fake sends the logs it has, then closes the stream.
+	if since == nil && until == nil {
+		writeNDJSON(w, entries)
+		return
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	if query.Get("order") == string(client.TimeDesc) {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].Time.After(entries[j].Time)
+		})
+	}
+
+	// The cursor is the offset of the next entry
+	offset := 0
+	if cursor := query.Get("cursor"); cursor != "" {
+		offset, err = strconv.Atoi(cursor)
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "invalidQueryParameter", fmt.Sprintf("Invalid cursor %s.", cursor))
+			return
+		}
+	}
+	limit, err := intParam(query, "limit", defaultAppLogsLimit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidQueryParameter", err.Error())
+		return
+	}
+
+	offset = min(offset, len(entries))
+	end := min(offset+limit, len(entries))
+	if end < len(entries) {
+		next := cloneQuery(query)
+		next.Set("cursor", strconv.Itoa(end))
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, pageURL(r, next)))
+	}
+
+	writeJSON(w, http.StatusOK, entries[offset:end])
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request, rawOrganizationID string) {
+	if !s.checkOrganization(w, rawOrganizationID) {
+		return
+	}
+
+	domains := make([]client.Domain, 0, len(s.domains))
+	for _, d := range s.domains {
+		domains = append(domains, d.toAPI())
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].CreatedAt.Before(domains[j].CreatedAt)
+	})
+
+	writePage(w, r, domains)
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request, rawOrganizationID string) {
+	if !s.checkOrganization(w, rawOrganizationID) {
+		return
+	}
+
+	var req client.CreateDomainRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	for _, d := range s.domains {
+		if d.Domain.Domain == req.Domain {
+			writeError(w, http.StatusConflict, "domainAlreadyExists", fmt.Sprintf("The domain %s already exists.", req.Domain))
+			return
+		}
+	}
+
+	now := time.Now().UTC()
+	token := randomID(32)
+	d := &domain{
+		Domain: client.Domain{
+			Id:             uuid.New(),
+			Domain:         req.Domain,
+			OrganizationId: s.OrganizationID,
+			Token:          token,
+			DnsRecords: []client.DnsRecord{
+				{Type: "A", Name: "@", Content: "34.120.54.55"},
+				{Type: "AAAA", Name: "@", Content: "2600:1901:0:6d85::"},
+				{Type: "CNAME", Name: "_acme-challenge", Content: fmt.Sprintf("%s.acme.deno.dev.", token)},
+			},
+			Certificates: []client.DomainCertificate{},
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+		provisioning: "pending",
+		verifiable:   true,
+	}
+	s.domains[d.Id] = d
+
+	writeJSON(w, http.StatusOK, d.toAPI())
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request, rawID string) {
+	d, ok := s.findDomain(w, rawID)
+	if !ok {
+		return
+	}
+
+	response := d.toAPI()
+	// Report the provisioning in progress on the first reads, then complete
+	if d.provisioning == "provisioning" {
+		d.pendingReads--
+	}
+	if d.provisioning == "provisioning" && d.pendingReads <= 0 {
+		if d.provisioningFailure != "" {
+			d.provisioning = "failed"
+		} else {
+			d.provisioning = "success"
+			now := time.Now().UTC()
+			d.Certificates = []client.DomainCertificate{
+				{Cipher: client.Rsa, CreatedAt: now, UpdatedAt: now, ExpiresAt: now.AddDate(0, 3, 0)},
+				{Cipher: client.Ec, CreatedAt: now, UpdatedAt: now, ExpiresAt: now.AddDate(0, 3, 0)},
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) updateDomainAssociation(w http.ResponseWriter, r *http.Request, rawID string) {
+	d, ok := s.findDomain(w, rawID)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidRequestBody", err.Error())
+		return
+	}
+	s.associations[d.Id] = append(s.associations[d.Id], body)
+
+	var req client.UpdateDomainAssociationRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidRequestBody", err.Error())
+		return
+	}
+
+	if req.DeploymentId == nil {
+		d.deploymentID = nil
+		d.ProjectId = nil
+	} else {
+		dep, ok := s.deployments[*req.DeploymentId]
+		if !ok {
+			writeError(w, http.StatusNotFound, "deploymentNotFound", fmt.Sprintf("Deployment %s was not found.", *req.DeploymentId))
+			return
+		}
+		if !d.IsValidated {
+			writeError(w, http.StatusBadRequest, "domainNotValidated", fmt.Sprintf("The ownership of the domain %s has not been verified.", d.Domain.Domain))
+			return
+		}
+		d.deploymentID = &dep.Id
+		projectID := dep.ProjectId
+		d.ProjectId = &projectID
+	}
+	d.UpdatedAt = time.Now().UTC()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) deleteDomain(w http.ResponseWriter, r *http.Request, rawID string) {
+	d, ok := s.findDomain(w, rawID)
+	if !ok {
+		return
+	}
+
+	delete(s.domains, d.Id)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) verifyDomain(w http.ResponseWriter, r *http.Request, rawID string) {
+	d, ok := s.findDomain(w, rawID)
+	if !ok {
+		return
+	}
+
+	if !d.verifiable {
+		writeError(w, http.StatusBadRequest, "domainVerificationFailed", fmt.Sprintf("The DNS records of the domain %s are not set up correctly.", d.Domain.Domain))
+		return
+	}
+	d.IsValidated = true
+	d.UpdatedAt = time.Now().UTC()
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) addDomainCertificate(w http.ResponseWriter, r *http.Request, rawID string) {
+	d, ok := s.findDomain(w, rawID)
+	if !ok {
+		return
+	}
+
+	var req client.AddDomainCertificateRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	// The fake doesn't check the chain against the private key, but reports
+	// the cipher and the expiry of the leaf certificate like the real API
+	block, _ := pem.Decode([]byte(req.CertificateChain))
+	if block == nil || block.Type != "CERTIFICATE" || !strings.Contains(req.PrivateKey, "PRIVATE KEY") {
+		writeError(w, http.StatusBadRequest, "invalidCertificate", "The certificate chain or the private key is not PEM encoded.")
+		return
+	}
+	leaf, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalidCertificate", fmt.Sprintf("The certificate could not be parsed: %s", err))
+		return
+	}
+	var cipher client.TlsCipher
+	switch leaf.PublicKey.(type) {
+	case *rsa.PublicKey:
+		cipher = client.Rsa
+	case *ecdsa.PublicKey:
+		cipher = client.Ec
+	default:
+		writeError(w, http.StatusBadRequest, "invalidCertificate", fmt.Sprintf("The public key type %T is not supported.", leaf.PublicKey))
+		return
+	}
+
+	now := time.Now().UTC()
+	certificate := client.DomainCertificate{
+		Cipher:    cipher,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: leaf.NotAfter.UTC(),
+	}
+	replaced := false
+	for i, c := range d.Certificates {
+		if c.Cipher == certificate.Cipher {
+			d.Certificates[i] = certificate
+			replaced = true
+		}
+	}
+	if !replaced {
+		d.Certificates = append(d.Certificates, certificate)
+	}
+	d.provisioning = "manual"
+	d.UpdatedAt = now
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) provisionDomainCertificates(w http.ResponseWriter, r *http.Request, rawID string) {
+	d, ok := s.findDomain(w, rawID)
+	if !ok {
+		return
+	}
+
+	if !d.IsValidated {
+		writeError(w, http.StatusBadRequest, "domainNotValidated", fmt.Sprintf("The ownership of the domain %s has not been verified.", d.Domain.Domain))
+		return
+	}
+	if d.provisioning != "manual" {
+		d.provisioning = "provisioning"
+		d.pendingReads = max(d.provisioningDelay, 1)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// toAPI converts the domain to the representation returned by the API.
+func (d *domain) toAPI() client.Domain {
+	ret := d.Domain
+	ret.Certificates = append([]client.DomainCertificate{}, d.Certificates...)
+
+	var err error
+	switch d.provisioning {
+	case "success":
+		err = ret.ProvisioningStatus.FromProvisioningStatusSuccess(client.ProvisioningStatusSuccess{Code: client.Success})
+	case "failed":
+		err = ret.ProvisioningStatus.FromProvisioningStatusFailed(client.ProvisioningStatusFailed{Code: client.ProvisioningStatusFailedCodeFailed, Message: d.provisioningFailure})
+	case "manual":
+		err = ret.ProvisioningStatus.FromProvisioningStatusManual(client.ProvisioningStatusManual{Code: client.Manual})
+	default:
+		err = ret.ProvisioningStatus.FromProvisioningStatusPending(client.ProvisioningStatusPending{Code: client.Pending})
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return ret
+}
+
+func (s *Server) checkOrganization(w http.ResponseWriter, rawID string) bool {
+	if rawID != s.OrganizationID.String() {
+		writeError(w, http.StatusNotFound, "organizationNotFound", fmt.Sprintf("Organization %s was not found.", rawID))
+		return false
+	}
+	return true
+}
+
+func (s *Server) projectNameTaken(name string) bool {
+	for _, p := range s.projects {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) findProject(w http.ResponseWriter, rawID string) (*client.Project, bool) {
+	id, err := uuid.Parse(rawID)
+	if err == nil {
+		if project, ok := s.projects[id]; ok {
+			return project, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "projectNotFound", fmt.Sprintf("Project %s was not found.", rawID))
+	return nil, false
+}
+
+func (s *Server) findDomain(w http.ResponseWriter, rawID string) (*domain, bool) {
+	id, err := uuid.Parse(rawID)
+	if err == nil {
+		if d, ok := s.domains[id]; ok {
+			return d, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "domainNotFound", fmt.Sprintf("Domain %s was not found.", rawID))
+	return nil, false
+}
+
+func (s *Server) findDeployment(w http.ResponseWriter, id string) (*deployment, bool) {
+	if d, ok := s.deployments[id]; ok {
+		return d, true
+	}
+	writeError(w, http.StatusNotFound, "deploymentNotFound", fmt.Sprintf("Deployment %s was not found.", id))
+	return nil, false
+}
+
+// decodeFileAsset returns the git SHA-1 hash of the file asset, along with its
+// content if it's given in the asset.
+func decodeFileAsset(asset client.Asset) (string, []byte, error) {
+	fileAsset, err := asset.AsFileAsset()
+	if err != nil {
+		return "", nil, err
+	}
+
+	withHash, err := fileAsset.AsFileAsset1()
+	if err == nil && withHash.GitSha1 != "" {
+		return withHash.GitSha1, nil, nil
+	}
+
+	withContent, err := fileAsset.AsFileAsset0()
+	if err != nil {
+		return "", nil, err
+	}
+	content := []byte(withContent.Content)
+	if withContent.Encoding != nil && *withContent.Encoding == client.Base64 {
+		content, err = base64.StdEncoding.DecodeString(withContent.Content)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil)), content, nil
+}
+
+// writePage writes the page of items specified by the page and limit query
+// parameters, along with the Link header pointing to the other pages.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	query := r.URL.Query()
+	page, err := intParam(query, "page", 1)
+	if err != nil || page < 1 {
+		writeError(w, http.StatusBadRequest, "invalidQueryParameter", fmt.Sprintf("Invalid page %s.", query.Get("page")))
+		return
+	}
+	limit, err := intParam(query, "limit", defaultPageSize)
+	if err != nil || limit < 1 || limit > maxPageSize {
+		writeError(w, http.StatusBadRequest, "invalidQueryParameter", fmt.Sprintf("Invalid limit %s.", query.Get("limit")))
+		return
+	}
+
+	lastPage := max((len(items)+limit-1)/limit, 1)
+	links := []string{}
+	addLink := func(rel string, p int) {
+		q := cloneQuery(query)
+		q.Set("page", strconv.Itoa(p))
+		q.Set("limit", strconv.Itoa(limit))
+		links = append(links, fmt.Sprintf(`<%s>; rel="%s"`, pageURL(r, q), rel))
+	}
+	if page > 1 {
+		addLink("prev", page-1)
+	}
+	if page < lastPage {
+		addLink("next", page+1)
+	}
+	addLink("first", 1)
+	addLink("last", lastPage)
+	w.Header().Set("Link", strings.Join(links, ", "))
+
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	writeJSON(w, http.StatusOK, items[start:end])
+}
+
+func writeNDJSON[T any](w http.ResponseWriter, entries []T) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		_ = encoder.Encode(entry)
+	}
+}
+
+func pageURL(r *http.Request, query url.Values) string {
+	u := url.URL{
+		Scheme:   "http",
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func cloneQuery(query url.Values) url.Values {
+	ret := url.Values{}
+	for key, values := range query {
+		ret[key] = append([]string{}, values...)
+	}
+	return ret
+}
+
+func intParam(query url.Values, name string, defaultValue int) (int, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s", name, raw)
+	}
+	return v, nil
+}
+
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %s", name, raw)
+	}
+	return &t, nil
+}
+
+// splitParam returns the set of the comma-separated values of the query
+// parameter, or nil if it's not given.
+func splitParam(query url.Values, name string) map[string]bool {
+	raw := query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	ret := map[string]bool{}
+	for _, v := range strings.Split(raw, ",") {
+		ret[v] = true
+	}
+	return ret
+}
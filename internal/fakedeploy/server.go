@@ -0,0 +1,441 @@
+// Package fakedeploy provides an in-memory fake of the Deno Deploy API, which
+// allows running the acceptance tests without access to the real API.
+//
+// The fake implements every endpoint of client.ClientInterface. Projects,
+// domains, deployments and their logs are kept in memory and discarded when
+// the server is closed. Requests to the domains of a deployment are answered
+// by the App registered for its entry point, since the fake can't run the
+// deployed code. Builds and certificate provisioning complete
+// instantly, although they are reported as pending on the first read so that
+// the polling logic of the provider is exercised.
+package fakedeploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-deno/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DEFAULT_TOKEN is the access token accepted by the server unless Token
+	// is changed.
+	DEFAULT_TOKEN = "ddp_fake_token"
+)
+
+// Server is an in-memory fake of the Deno Deploy API.
+type Server struct {
+	*httptest.Server
+
+	// OrganizationID is the ID of the only organization that exists.
+	OrganizationID uuid.UUID
+	// Token is the access token that requests need to bear.
+	Token string
+
+	mu             sync.Mutex
+	organization   client.Organization
+	projects       map[uuid.UUID]*client.Project
+	analytics      map[uuid.UUID]client.Analytics
+	domains        map[uuid.UUID]*domain
+	deployments    map[string]*deployment
+	uploadedHashes map[uuid.UUID]map[string]bool
+	received       map[uuid.UUID][]ReceivedDeployment
+	associations   map[uuid.UUID][]json.RawMessage
+	blobs          map[string][]byte
+	apps           map[string]App
+	buildDuration  time.Duration
+	failures       []*Failure
+}
+
+// domain is a domain with the state that is not exposed through the API.
+type domain struct {
+	client.Domain
+	provisioning        string
+	provisioningFailure string
+	provisioningDelay   int
+	pendingReads        int
+	verifiable          bool
+	deploymentID        *string
+}
+
+// deployment is a deployment with the state that is not exposed through the
+// API.
+type deployment struct {
+	client.Deployment
+	finalStatus client.DeploymentStatus
+	builtAt     time.Time
+	app         DeployedApp
+	buildLogs   []client.BuildLogsResponseEntry
+	appLogs     []client.AppLogsResponseEntry
+}
+
+// Failure describes an error response returned instead of handling matching
+// requests.
+type Failure struct {
+	// Method is the HTTP method to match. Empty matches any method.
+	Method string
+	// Path is the prefix of the request path to match, such as "/projects/".
+	// Empty matches any path.
+	Path string
+	// StatusCode is the status code of the error response.
+	StatusCode int
+	// Code is the error code in the error response body. Defaults to
+	// "injectedFailure".
+	Code string
+	// Header is added to the error response, e.g. to set Retry-After.
+	Header http.Header
+	// Times is the number of matching requests to fail. Zero fails every
+	// matching request.
+	Times int
+}
+
+// DeployedApp is the application made of a deployment.
+type DeployedApp struct {
+	// EntryPoint is the path of the entry point, such as "main.ts".
+	EntryPoint string
+	// Files are the contents of the file assets, keyed by their paths.
+	Files map[string][]byte
+	// Symlinks are the targets of the symlink assets, keyed by their paths.
+	Symlinks map[string]string
+	// EnvVars are the environment variables of the deployment.
+	EnvVars map[string]string
+}
+
+// App emulates the application of a deployment. It returns the response body
+// to the requests sent to the domains of the deployment.
+type App func(app DeployedApp) ([]byte, error)
+
+// ReceivedDeployment is a request to create a deployment that the server
+// received, whether it was accepted or not.
+type ReceivedDeployment struct {
+	// Request is the body of the request.
+	Request client.CreateDeploymentRequest
+	// StatusCode is the status code of the response.
+	StatusCode int
+}
+
+// HashOnlyAssets returns the paths of the file assets that were sent with
+// their hashes only, i.e. as FileAsset1, sorted.
+func (d ReceivedDeployment) HashOnlyAssets() []string {
+	paths := []string{}
+	for path, asset := range d.Request.Assets {
+		if kind, err := asset.Discriminator(); err != nil || kind != string(client.FileAssetKindFile) {
+			continue
+		}
+		if _, content, err := decodeFileAsset(asset); err == nil && content == nil {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// NewServer starts a fake Deno Deploy API server with a single organization.
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	now := time.Now().UTC()
+	s := &Server{
+		OrganizationID: uuid.New(),
+		Token:          DEFAULT_TOKEN,
+		projects:       map[uuid.UUID]*client.Project{},
+		analytics:      map[uuid.UUID]client.Analytics{},
+		domains:        map[uuid.UUID]*domain{},
+		deployments:    map[string]*deployment{},
+		uploadedHashes: map[uuid.UUID]map[string]bool{},
+		received:       map[uuid.UUID][]ReceivedDeployment{},
+		associations:   map[uuid.UUID][]json.RawMessage{},
+		blobs:          map[string][]byte{},
+		apps:           map[string]App{},
+	}
+	s.organization = client.Organization{
+		Id:        s.OrganizationID,
+		Name:      "fake-organization",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// InjectFailure makes the server respond to the requests matching f with an
+// error response.
+func (s *Server) InjectFailure(f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &f)
+}
+
+// ClearFailures removes all the failures injected with InjectFailure.
+func (s *Server) ClearFailures() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = nil
+}
+
+// SetDomainVerifiable sets whether the ownership verification of the domain
+// succeeds. Verification succeeds by default.
+func (s *Server) SetDomainVerifiable(domainID uuid.UUID, verifiable bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.domains[domainID]; ok {
+		d.verifiable = verifiable
+	}
+}
+
+// FailCertificateProvisioning makes certificate provisioning of the domain
+// fail with the given message.
+func (s *Server) FailCertificateProvisioning(domainID uuid.UUID, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.domains[domainID]; ok {
+		d.provisioningFailure = message
+	}
+}
+
+// DelayCertificateProvisioning makes certificate provisioning of the domain
+// report pending on the given number of reads of the domain before it
+// completes, instead of only on the first one.
+func (s *Server) DelayCertificateProvisioning(domainID uuid.UUID, reads int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.domains[domainID]; ok {
+		d.provisioningDelay = reads
+	}
+}
+
+// AddAppLogs appends application logs to the deployment.
+func (s *Server) AddAppLogs(deploymentID string, entries ...client.AppLogsResponseEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.deployments[deploymentID]; ok {
+		d.appLogs = append(d.appLogs, entries...)
+	}
+}
+
+// ServeApp registers the App emulating the deployments whose entry point is
+// the given path, such as "main.ts". Requests to a deployment without an App
+// are responded with 502.
+func (s *Server) ServeApp(entryPoint string, app App) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apps[entryPoint] = app
+}
+
+// SetBuildDuration sets how long the builds of the deployments created
+// afterwards take. While a build is running, its build log stream stays open
+// after the first log and the deployment is reported as pending. Builds
+// complete instantly by default.
+func (s *Server) SetBuildDuration(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buildDuration = d
+}
+
+// ForgetUploadedAssets makes the server forget the contents of the files
+// uploaded to the project, so that the next deployment referring to them by
+// hash only is rejected.
+func (s *Server) ForgetUploadedAssets(projectID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uploadedHashes, projectID)
+}
+
+// ReceivedDeployments returns the requests to create a deployment of the
+// project that the server received, in order.
+func (s *Server) ReceivedDeployments(projectID uuid.UUID) []ReceivedDeployment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ReceivedDeployment{}, s.received[projectID]...)
+}
+
+// DomainDeploymentID returns the ID of the deployment the domain is
+// associated with, or nil if it is not associated with any deployment or does
+// not exist.
+func (s *Server) DomainDeploymentID(domainID uuid.UUID) *string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d, ok := s.domains[domainID]; ok && d.deploymentID != nil {
+		id := *d.deploymentID
+		return &id
+	}
+	return nil
+}
+
+// DomainAssociationRequests returns the bodies of the requests to update the
+// association of the domain that the server received, in order. They are
+// kept after the domain is deleted.
+func (s *Server) DomainAssociationRequests(domainID uuid.UUID) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]json.RawMessage{}, s.associations[domainID]...)
+}
+
+// SetAnalytics sets the analytics data returned for the project.
+func (s *Server) SetAnalytics(projectID uuid.UUID, analytics client.Analytics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.analytics[projectID] = analytics
+}
+
+// handle authenticates the request, applies injected failures and dispatches
+// the request to the handler of the endpoint.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Requests to the domains of a deployment don't go to the API
+	if d := s.findDeploymentByHost(r.Host); d != nil {
+		s.serveApp(w, d)
+		return
+	}
+
+	w.Header().Set(client.X_DENO_RAY, randomID(16))
+
+	if r.Header.Get("Authorization") != "Bearer "+s.Token {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "The access token is missing or invalid.")
+		return
+	}
+
+	if f := s.matchFailure(r); f != nil {
+		for key, values := range f.Header {
+			for _, value := range values {
+				w.Header().Add(key, value)
+			}
+		}
+		code := f.Code
+		if code == "" {
+			code = "injectedFailure"
+		}
+		writeError(w, f.StatusCode, code, "The failure was injected by the fake server.")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	// Allow the host to contain the version prefix
+	if len(segments) > 0 && segments[0] == "v1" {
+		segments = segments[1:]
+	}
+
+	route := fmt.Sprintf("%s /%s", r.Method, strings.Join(segments, "/"))
+	switch {
+	case match(segments, "organizations", "*") && r.Method == http.MethodGet:
+		s.getOrganization(w, r, segments[1])
+	case match(segments, "organizations", "*", "projects") && r.Method == http.MethodGet:
+		s.listProjects(w, r, segments[1])
+	case match(segments, "organizations", "*", "projects") && r.Method == http.MethodPost:
+		s.createProject(w, r, segments[1])
+	case match(segments, "organizations", "*", "domains") && r.Method == http.MethodGet:
+		s.listDomains(w, r, segments[1])
+	case match(segments, "organizations", "*", "domains") && r.Method == http.MethodPost:
+		s.createDomain(w, r, segments[1])
+	case match(segments, "projects", "*") && r.Method == http.MethodGet:
+		s.getProject(w, r, segments[1])
+	case match(segments, "projects", "*") && r.Method == http.MethodPatch:
+		s.updateProject(w, r, segments[1])
+	case match(segments, "projects", "*") && r.Method == http.MethodDelete:
+		s.deleteProject(w, r, segments[1])
+	case match(segments, "projects", "*", "analytics") && r.Method == http.MethodGet:
+		s.getProjectAnalytics(w, r, segments[1])
+	case match(segments, "projects", "*", "deployments") && r.Method == http.MethodGet:
+		s.listDeployments(w, r, segments[1])
+	case match(segments, "projects", "*", "deployments") && r.Method == http.MethodPost:
+		s.createDeployment(w, r, segments[1])
+	case match(segments, "deployments", "*") && r.Method == http.MethodGet:
+		s.getDeployment(w, r, segments[1])
+	case match(segments, "deployments", "*", "build_logs") && r.Method == http.MethodGet:
+		s.getBuildLogs(w, r, segments[1])
+	case match(segments, "deployments", "*", "app_logs") && r.Method == http.MethodGet:
+		s.getAppLogs(w, r, segments[1])
+	case match(segments, "domains", "*") && r.Method == http.MethodGet:
+		s.getDomain(w, r, segments[1])
+	case match(segments, "domains", "*") && r.Method == http.MethodPatch:
+		s.updateDomainAssociation(w, r, segments[1])
+	case match(segments, "domains", "*") && r.Method == http.MethodDelete:
+		s.deleteDomain(w, r, segments[1])
+	case match(segments, "domains", "*", "verify") && r.Method == http.MethodPost:
+		s.verifyDomain(w, r, segments[1])
+	case match(segments, "domains", "*", "certificates") && r.Method == http.MethodPost:
+		s.addDomainCertificate(w, r, segments[1])
+	case match(segments, "domains", "*", "certificates", "provision") && r.Method == http.MethodPost:
+		s.provisionDomainCertificates(w, r, segments[1])
+	default:
+		writeError(w, http.StatusNotFound, "routeNotFound", fmt.Sprintf("No route for %s.", route))
+	}
+}
+
+// matchFailure returns the first injected failure matching the request, and
+// consumes it.
+func (s *Server) matchFailure(r *http.Request) *Failure {
+	for i, f := range s.failures {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// match returns true if the path segments match the pattern, where "*"
+// matches any single segment.
+func match(segments []string, pattern ...string) bool {
+	if len(segments) != len(pattern) {
+		return false
+	}
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code string, message string) {
+	writeJSON(w, statusCode, client.ErrorBody{
+		Code:    code,
+		Message: message,
+	})
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalidRequestBody", err.Error())
+		return false
+	}
+	return true
+}
+
+const idLetters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// randomID returns a random string of lowercase letters and digits, such as
+// the one used for deployment IDs.
+func randomID(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = idLetters[rand.Intn(len(idLetters))]
+	}
+	return string(b)
+}
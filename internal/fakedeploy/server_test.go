@@ -0,0 +1,421 @@
+package fakedeploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-deno/client"
+)
+
+func newTestClient(t *testing.T, s *Server) *client.ClientWithResponses {
+	addAuth := func(ctx context.Context, req *http.Request) error {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.Token))
+		return nil
+	}
+	c, err := client.NewClientWithResponses(s.URL, client.WithRequestEditorFn(addAuth))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestProjects(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	name := "my-project"
+	created, err := c.CreateProjectWithResponse(ctx, s.OrganizationID, client.CreateProjectRequest{Name: &name})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("Failed to create project: %v, %s", err, created.Body)
+	}
+
+	// The name must be unique
+	conflict, err := c.CreateProjectWithResponse(ctx, s.OrganizationID, client.CreateProjectRequest{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !client.IsConflict(client.CheckResponse(conflict)) {
+		t.Errorf("Expected conflict, got status %d", conflict.StatusCode())
+	}
+
+	updated, err := c.UpdateProjectWithResponse(ctx, created.JSON200.Id, client.UpdateProjectRequest{Name: "renamed"})
+	if err != nil || updated.JSON200 == nil || updated.JSON200.Name != "renamed" {
+		t.Fatalf("Failed to update project: %v, %s", err, updated.Body)
+	}
+
+	deleted, err := c.DeleteProjectWithResponse(ctx, created.JSON200.Id)
+	if err != nil || client.CheckResponse(deleted) != nil {
+		t.Fatalf("Failed to delete project: %v, %s", err, deleted.Body)
+	}
+
+	got, err := c.GetProjectWithResponse(ctx, created.JSON200.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !client.IsNotFound(client.CheckResponse(got)) {
+		t.Errorf("Expected not found, got status %d", got.StatusCode())
+	}
+}
+
+func TestListPagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if _, err := c.CreateProjectWithResponse(ctx, s.OrganizationID, client.CreateProjectRequest{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	page, limit := 2, 2
+	projects, err := c.ListProjectsWithResponse(ctx, s.OrganizationID, &client.ListProjectsParams{Page: &page, Limit: &limit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*projects.JSON200) != 2 {
+		t.Errorf("Expected 2 projects, got %d", len(*projects.JSON200))
+	}
+	link := projects.GetHeaders().Get("Link")
+	for _, rel := range []string{`rel="prev"`, `rel="next"`, `rel="first"`, `rel="last"`} {
+		if !strings.Contains(link, rel) {
+			t.Errorf("Expected Link header to contain %s, got %s", rel, link)
+		}
+	}
+}
+
+func TestDeployment(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	project, err := c.CreateProjectWithResponse(ctx, s.OrganizationID, client.CreateProjectRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A hash that has never been uploaded is rejected
+	var hashOnly client.Asset
+	var hashOnlyFile client.FileAsset
+	if err := hashOnlyFile.FromFileAsset1(client.FileAsset1{GitSha1: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"}); err != nil {
+		t.Fatal(err)
+	}
+	hashOnlyFile.Kind = client.FileAssetKindFile
+	if err := hashOnly.FromFileAsset(hashOnlyFile); err != nil {
+		t.Fatal(err)
+	}
+	rejected, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+		EntryPointUrl: "main.ts",
+		Assets:        client.Assets{"main.ts": hashOnly},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rejected.JSON400 == nil {
+		t.Errorf("Expected hash-only asset to be rejected, got status %d", rejected.StatusCode())
+	}
+
+	var file client.Asset
+	var fileWithContent client.FileAsset
+	if err := fileWithContent.FromFileAsset0(client.FileAsset0{Content: ""}); err != nil {
+		t.Fatal(err)
+	}
+	fileWithContent.Kind = client.FileAssetKindFile
+	if err := file.FromFileAsset(fileWithContent); err != nil {
+		t.Fatal(err)
+	}
+	created, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+		EntryPointUrl: "main.ts",
+		Assets:        client.Assets{"main.ts": file},
+	})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("Failed to create deployment: %v, %s", err, created.Body)
+	}
+	if created.JSON200.Status != client.DeploymentStatusPending {
+		t.Errorf("Expected pending deployment, got %s", created.JSON200.Status)
+	}
+
+	var logs []client.BuildLogsResponseEntry
+	if err := client.StreamBuildLogs(ctx, c.ClientInterface, created.JSON200.Id, func(entry client.BuildLogsResponseEntry) {
+		logs = append(logs, entry)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) == 0 {
+		t.Errorf("Expected build logs")
+	}
+
+	got, err := c.GetDeploymentWithResponse(ctx, created.JSON200.Id)
+	if err != nil || got.JSON200 == nil {
+		t.Fatalf("Failed to get deployment: %v, %s", err, got.Body)
+	}
+	if got.JSON200.Status != client.DeploymentStatusSuccess {
+		t.Errorf("Expected successful deployment, got %s", got.JSON200.Status)
+	}
+
+	// Now the hash is known
+	redeployed, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+		EntryPointUrl: "main.ts",
+		Assets:        client.Assets{"main.ts": hashOnly},
+	})
+	if err != nil || redeployed.JSON200 == nil {
+		t.Fatalf("Failed to redeploy with hash-only asset: %v, %s", err, redeployed.Body)
+	}
+
+	received := s.ReceivedDeployments(project.JSON200.Id)
+	expected := []struct {
+		statusCode     int
+		hashOnlyAssets int
+	}{
+		{http.StatusBadRequest, 1},
+		{http.StatusOK, 0},
+		{http.StatusOK, 1},
+	}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %d received deployments, got %d", len(expected), len(received))
+	}
+	for i, e := range expected {
+		if received[i].StatusCode != e.statusCode || len(received[i].HashOnlyAssets()) != e.hashOnlyAssets {
+			t.Errorf("Received deployment %d: expected status %d with %d hash-only assets, got %d with %v", i, e.statusCode, e.hashOnlyAssets, received[i].StatusCode, received[i].HashOnlyAssets())
+		}
+	}
+
+	// The hash is unknown again once forgotten
+	s.ForgetUploadedAssets(project.JSON200.Id)
+	forgotten, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+		EntryPointUrl: "main.ts",
+		Assets:        client.Assets{"main.ts": hashOnly},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forgotten.JSON400 == nil || forgotten.JSON400.Code != "assetNotFound" {
+		t.Errorf("Expected hash-only asset to be rejected after forgetting, got status %d", forgotten.StatusCode())
+	}
+}
+
+func TestDomainProvisioning(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	created, err := c.CreateDomainWithResponse(ctx, s.OrganizationID, client.CreateDomainRequest{Domain: "foo.example.com"})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("Failed to create domain: %v, %s", err, created.Body)
+	}
+	domainID := created.JSON200.Id
+
+	if _, err := c.VerifyDomainWithResponse(ctx, domainID); err != nil {
+		t.Fatal(err)
+	}
+	s.FailCertificateProvisioning(domainID, "CAA record forbids issuance")
+	if _, err := c.ProvisionDomainCertificatesWithResponse(ctx, domainID); err != nil {
+		t.Fatal(err)
+	}
+
+	var status any
+	for i := 0; i < 2; i++ {
+		got, err := c.GetDomainWithResponse(ctx, domainID)
+		if err != nil || got.JSON200 == nil {
+			t.Fatalf("Failed to get domain: %v, %s", err, got.Body)
+		}
+		status, err = got.JSON200.ProvisioningStatus.ValueByDiscriminator()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	failed, ok := status.(client.ProvisioningStatusFailed)
+	if !ok || failed.Message != "CAA record forbids issuance" {
+		t.Errorf("Expected failed provisioning, got %#v", status)
+	}
+}
+
+func TestDelayCertificateProvisioning(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	created, err := c.CreateDomainWithResponse(ctx, s.OrganizationID, client.CreateDomainRequest{Domain: "foo.example.com"})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("Failed to create domain: %v, %s", err, created.Body)
+	}
+	domainID := created.JSON200.Id
+
+	if _, err := c.VerifyDomainWithResponse(ctx, domainID); err != nil {
+		t.Fatal(err)
+	}
+	s.DelayCertificateProvisioning(domainID, 3)
+	if _, err := c.ProvisionDomainCertificatesWithResponse(ctx, domainID); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 4; i++ {
+		got, err := c.GetDomainWithResponse(ctx, domainID)
+		if err != nil || got.JSON200 == nil {
+			t.Fatalf("Failed to get domain: %v, %s", err, got.Body)
+		}
+		status, err := got.JSON200.ProvisioningStatus.ValueByDiscriminator()
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, pending := status.(client.ProvisioningStatusPending)
+		if pending != (i < 3) {
+			t.Errorf("Read %d: unexpected provisioning status %#v", i, status)
+		}
+	}
+}
+
+func TestInjectFailure(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	s.InjectFailure(Failure{
+		Method:     http.MethodGet,
+		Path:       "/organizations/",
+		StatusCode: http.StatusServiceUnavailable,
+		Times:      1,
+	})
+
+	failed, err := c.GetOrganizationWithResponse(ctx, s.OrganizationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed.StatusCode() != http.StatusServiceUnavailable {
+		t.Errorf("Expected injected failure, got status %d", failed.StatusCode())
+	}
+
+	// The failure is consumed
+	succeeded, err := c.GetOrganizationWithResponse(ctx, s.OrganizationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if succeeded.JSON200 == nil || succeeded.JSON200.Id != s.OrganizationID {
+		t.Errorf("Expected the organization, got status %d", succeeded.StatusCode())
+	}
+}
+
+func TestBuildDuration(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	project, err := c.CreateProjectWithResponse(ctx, s.OrganizationID, client.CreateProjectRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.SetBuildDuration(time.Hour)
+	created, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+		EntryPointUrl: "https://example.com/main.ts",
+	})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("Failed to create deployment: %v, %s", err, created.Body)
+	}
+
+	// The stream stays open after the first log until the build completes
+	streamCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	var logs []client.BuildLogsResponseEntry
+	err = client.StreamBuildLogs(streamCtx, c.ClientInterface, created.JSON200.Id, func(entry client.BuildLogsResponseEntry) {
+		logs = append(logs, entry)
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the stream to time out, got %v", err)
+	}
+	if len(logs) != 1 {
+		t.Errorf("Expected the first log only, got %v", logs)
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := c.GetDeploymentWithResponse(ctx, created.JSON200.Id)
+		if err != nil || got.JSON200 == nil {
+			t.Fatalf("Failed to get deployment: %v, %s", err, got.Body)
+		}
+		if got.JSON200.Status != client.DeploymentStatusPending {
+			t.Errorf("Expected pending deployment while building, got %s", got.JSON200.Status)
+		}
+	}
+}
+
+func TestServeApp(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(t, s)
+	ctx := context.Background()
+
+	project, err := c.CreateProjectWithResponse(ctx, s.OrganizationID, client.CreateProjectRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.ServeApp("main.ts", func(app DeployedApp) ([]byte, error) {
+		return []byte(fmt.Sprintf("%s %s", app.Files["main.ts"], app.EnvVars["FOO"])), nil
+	})
+
+	var file client.Asset
+	var fileWithContent client.FileAsset
+	if err := fileWithContent.FromFileAsset0(client.FileAsset0{Content: "Hello"}); err != nil {
+		t.Fatal(err)
+	}
+	fileWithContent.Kind = client.FileAssetKindFile
+	if err := file.FromFileAsset(fileWithContent); err != nil {
+		t.Fatal(err)
+	}
+	created, err := c.CreateDeploymentWithResponse(ctx, project.JSON200.Id, client.CreateDeploymentRequest{
+		EntryPointUrl: "main.ts",
+		Assets:        client.Assets{"main.ts": file},
+		EnvVars:       map[string]string{"FOO": "fake"},
+	})
+	if err != nil || created.JSON200 == nil {
+		t.Fatalf("Failed to create deployment: %v, %s", err, created.Body)
+	}
+	if len(*created.JSON200.Domains) != 1 {
+		t.Fatalf("Expected a domain, got %v", *created.JSON200.Domains)
+	}
+	domain := (*created.JSON200.Domains)[0]
+
+	get := func() (int, string) {
+		req, err := http.NewRequest(http.MethodGet, s.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = domain
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	// The app is not served until the build completes
+	if status, _ := get(); status != http.StatusUnauthorized {
+		t.Errorf("Expected the domain to be unavailable while building, got status %d", status)
+	}
+
+	if _, err := c.GetDeploymentWithResponse(ctx, created.JSON200.Id); err != nil {
+		t.Fatal(err)
+	}
+	if status, body := get(); status != http.StatusOK || body != "Hello fake" {
+		t.Errorf("Expected the app to respond, got status %d: %s", status, body)
+	}
+}
@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &appLogsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &appLogsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &appLogsDataSource{}
+)
+
+const (
+	// DEFAULT_APP_LOGS_LIMIT is the maximum number of log entries returned by
+	// the data source unless limit is set.
+	DEFAULT_APP_LOGS_LIMIT = 100
+	// APP_LOGS_PAGE_SIZE is the number of log entries fetched per request.
+	APP_LOGS_PAGE_SIZE = 100
+)
+
+var appLogType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"time":    types.StringType,
+		"level":   types.StringType,
+		"region":  types.StringType,
+		"message": types.StringType,
+	},
+}
+
+// NewAppLogsDataSource is a helper function to simplify the provider implementation.
+func NewAppLogsDataSource() datasource.DataSource {
+	return &appLogsDataSource{}
+}
+
+// appLogsDataSource is the data source implementation.
+type appLogsDataSource struct {
+	client client.ClientWithResponsesInterface
+}
+
+// appLogsDataSourceModel maps the data source schema data.
+type appLogsDataSourceModel struct {
+	DeploymentID types.String `tfsdk:"deployment_id"`
+	Query        types.String `tfsdk:"query"`
+	Levels       types.List   `tfsdk:"levels"`
+	Regions      types.List   `tfsdk:"regions"`
+	Since        types.String `tfsdk:"since"`
+	Until        types.String `tfsdk:"until"`
+	Order        types.String `tfsdk:"order"`
+	Limit        types.Int64  `tfsdk:"limit"`
+	Logs         types.List   `tfsdk:"logs"`
+}
+
+// Metadata returns the data source type name.
+func (d *appLogsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app_logs"
+}
+
+// Schema defines the schema for the data source.
+func (d *appLogsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for the application logs of a deployment, i.e. the logs emitted while the deployment is running.
+
+The logs in the given time range are fetched, following the pagination of the API until the limit is reached. This is useful for smoke checks after a deployment, e.g. asserting that no error logs were emitted in the first minutes.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"deployment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the deployment.",
+			},
+			"query": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the logs whose messages contain this text.",
+			},
+			"levels": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only return the logs of these levels. Each level can be either `debug`, `info`, `warning` or `error`. Defaults to all levels.",
+			},
+			"regions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Only return the logs emitted in these regions, such as `gcp-us-east1`. Defaults to all regions.",
+			},
+			"since": schema.StringAttribute{
+				Required:            true,
+				Description:         "The start of the time range to return the logs of, formatted in RFC3339. Note that logs are retained for a limited period.",
+				MarkdownDescription: "The start of the time range to return the logs of, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339). Note that logs are retained for a limited period.",
+			},
+			"until": schema.StringAttribute{
+				Optional:            true,
+				Description:         "The end of the time range to return the logs of, formatted in RFC3339. Defaults to the current time.",
+				MarkdownDescription: "The end of the time range to return the logs of, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339). Defaults to the current time.",
+			},
+			"order": schema.StringAttribute{
+				Optional:    true,
+				Description: "The order of the logs by time. It can be either `asc` or `desc`. Defaults to `asc`.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("The maximum number of logs to return. Defaults to %d.", DEFAULT_APP_LOGS_LIMIT),
+			},
+			"logs": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The logs, sorted by time in the given order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"time": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The time the log was emitted, formatted in RFC3339.",
+							MarkdownDescription: "The time the log was emitted, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+						},
+						"level": schema.StringAttribute{
+							Computed:    true,
+							Description: "The level of the log, such as `info` or `error`.",
+						},
+						"region": schema.StringAttribute{
+							Computed:    true,
+							Description: "The region where the log was emitted.",
+						},
+						"message": schema.StringAttribute{
+							Computed:    true,
+							Description: "The message of the log.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// appLogsQuery is the parsed form of the filters of the data source.
+type appLogsQuery struct {
+	params client.GetAppLogsParams
+	limit  int
+}
+
+// ValidateConfig checks the filters.
+func (d *appLogsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config appLogsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags = config.query(ctx)
+	resp.Diagnostics.Append(diags...)
+}
+
+// query converts the filters set in the config to the parameters of the API.
+// Unknown values are ignored.
+func (m *appLogsDataSourceModel) query(ctx context.Context) (appLogsQuery, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	q := appLogsQuery{
+		limit: DEFAULT_APP_LOGS_LIMIT,
+	}
+
+	if m.Query.ValueString() != "" {
+		text := m.Query.ValueString()
+		q.params.Q = &text
+	}
+
+	if !m.Levels.IsNull() && !m.Levels.IsUnknown() {
+		var levels []string
+		diags.Append(m.Levels.ElementsAs(ctx, &levels, false)...)
+		for _, level := range levels {
+			switch client.LogLevel(level) {
+			case client.Debug, client.Info, client.Warning, client.Error:
+			default:
+				diags.AddAttributeError(
+					path.Root("levels"),
+					"Invalid Log Level",
+					fmt.Sprintf("Each level must be either debug, info, warning or error, got %s.", level),
+				)
+			}
+		}
+		if len(levels) > 0 {
+			level := client.LogLevel(strings.Join(levels, ","))
+			q.params.Level = &level
+		}
+	}
+
+	if !m.Regions.IsNull() && !m.Regions.IsUnknown() {
+		var regions []string
+		diags.Append(m.Regions.ElementsAs(ctx, &regions, false)...)
+		if len(regions) > 0 {
+			region := client.Region(strings.Join(regions, ","))
+			q.params.Region = &region
+		}
+	}
+
+	parseTime := func(name string, v types.String) *time.Time {
+		if v.IsNull() || v.IsUnknown() {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, v.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Invalid Time",
+				fmt.Sprintf("Could not parse %s as RFC3339: %s", v, err.Error()),
+			)
+			return nil
+		}
+		return &t
+	}
+	q.params.Since = parseTime("since", m.Since)
+	q.params.Until = parseTime("until", m.Until)
+
+	if !m.Order.IsNull() && !m.Order.IsUnknown() {
+		var order client.LogOrder
+		switch m.Order.ValueString() {
+		case "asc":
+			order = client.TimeAsc
+		case "desc":
+			order = client.TimeDesc
+		default:
+			diags.AddAttributeError(
+				path.Root("order"),
+				"Invalid Order",
+				fmt.Sprintf("order must be either asc or desc, got %s.", m.Order),
+			)
+		}
+		q.params.Order = &order
+	}
+
+	if !m.Limit.IsNull() && !m.Limit.IsUnknown() {
+		q.limit = int(m.Limit.ValueInt64())
+		if q.limit <= 0 {
+			diags.AddAttributeError(
+				path.Root("limit"),
+				"Invalid Limit",
+				fmt.Sprintf("limit must be positive, got %d.", q.limit),
+			)
+		}
+	}
+
+	return q, diags
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *appLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state appLogsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	q, diags := state.query(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// since is always set, so the API returns the past logs rather than
+	// streaming them in real time
+	deploymentID := state.DeploymentID.ValueString()
+	entries := []client.AppLogsResponseEntry{}
+	params := q.params
+	for len(entries) < q.limit {
+		pageSize := min(q.limit-len(entries), APP_LOGS_PAGE_SIZE)
+		params.Limit = &pageSize
+
+		logs, err := d.client.GetAppLogsWithResponse(ctx, deploymentID, &params)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read App Logs of Deployment %s", deploymentID),
+				err.Error(),
+			)
+			return
+		}
+		if err := client.CheckResponse(logs); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read App Logs of Deployment %s", deploymentID),
+				apiErrorDetail(err),
+			)
+			return
+		}
+		if logs.JSON200 != nil {
+			entries = append(entries, *logs.JSON200...)
+		}
+
+		// Follow the cursor in the next link, if any
+		next, ok := client.ParseLinkHeader(logs.GetHeaders().Get("Link"))["next"]
+		if !ok {
+			break
+		}
+		cursor := next.Query().Get("cursor")
+		if cursor == "" || logs.JSON200 == nil || len(*logs.JSON200) == 0 {
+			break
+		}
+		params.Cursor = &cursor
+
+		tflog.Debug(ctx, "Fetching the next page of app logs", map[string]any{
+			"deployment_id": deploymentID,
+			"fetched":       len(entries),
+		})
+	}
+	entries = entries[:min(len(entries), q.limit)]
+
+	list, diags := convertToAppLogsList(entries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Logs = list
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func convertToAppLogsList(entries []client.AppLogsResponseEntry) (types.List, diag.Diagnostics) {
+	logs := make([]attr.Value, len(entries))
+	for i, entry := range entries {
+		objectValue, diags := types.ObjectValue(appLogType.AttrTypes, map[string]attr.Value{
+			"time":    types.StringValue(entry.Time.Format(time.RFC3339Nano)),
+			"level":   types.StringValue(string(entry.Level)),
+			"region":  types.StringValue(string(entry.Region)),
+			"message": types.StringValue(entry.Message),
+		})
+		if diags.HasError() {
+			return types.ListNull(appLogType), diags
+		}
+		logs[i] = objectValue
+	}
+
+	appLogsList, diags := types.ListValue(appLogType, logs)
+	if diags.HasError() {
+		return types.ListNull(appLogType), diags
+	}
+
+	return appLogsList, nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *appLogsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+}
@@ -0,0 +1,102 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"terraform-provider-deno/client"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAppLogsDataSource(t *testing.T) {
+	if fakeServer == nil {
+		t.Skip("app logs can only be seeded on the fake server")
+	}
+
+	deploymentConfig := `
+		resource "deno_project" "test" {}
+
+		data "deno_assets" "test" {
+			glob = "testdata/single-file/main.ts"
+		}
+
+		resource "deno_deployment" "test" {
+			project_id = deno_project.test.id
+			entry_point_url = "testdata/single-file/main.ts"
+			assets = data.deno_assets.test.output
+			env_vars = {}
+		}
+	`
+	since := time.Now().Add(-time.Hour).UTC()
+	var deploymentID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				// The invalid configuration comes first, so that the resources
+				// are destroyed with a valid one at the end of the test
+				Config: deploymentConfig + `
+					data "deno_app_logs" "test" {
+						deployment_id = deno_deployment.test.deployment_id
+						since         = "2024-01-01T00:00:00Z"
+						levels        = ["fatal"]
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Log Level`),
+			},
+			{
+				Config: deploymentConfig,
+				Check: func(s *terraform.State) error {
+					deploymentID = s.RootModule().Resources["deno_deployment.test"].Primary.Attributes["deployment_id"]
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					// More entries than a page, so that the cursor is followed
+					for i := 0; i < 150; i++ {
+						level := client.Info
+						if i%50 == 0 {
+							level = client.Error
+						}
+						fakeServer.AddAppLogs(deploymentID, client.AppLogsResponseEntry{
+							Time:    since.Add(time.Duration(i) * time.Second),
+							Level:   level,
+							Region:  "gcp-us-east1",
+							Message: fmt.Sprintf("log %d", i),
+						})
+					}
+				},
+				Config: deploymentConfig + fmt.Sprintf(`
+					data "deno_app_logs" "all" {
+						deployment_id = deno_deployment.test.deployment_id
+						since         = "%[1]s"
+						limit         = 120
+					}
+
+					data "deno_app_logs" "errors" {
+						deployment_id = deno_deployment.test.deployment_id
+						since         = "%[1]s"
+						levels        = ["error"]
+						order         = "desc"
+					}
+				`, since.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.deno_app_logs.all", "logs.#", "120"),
+					resource.TestCheckResourceAttr("data.deno_app_logs.all", "logs.0.message", "log 0"),
+					resource.TestCheckResourceAttr("data.deno_app_logs.all", "logs.119.message", "log 119"),
+					resource.TestCheckResourceAttr("data.deno_app_logs.errors", "logs.#", "3"),
+					resource.TestCheckResourceAttr("data.deno_app_logs.errors", "logs.0.message", "log 100"),
+					resource.TestCheckResourceAttr("data.deno_app_logs.errors", "logs.0.level", "error"),
+					resource.TestCheckResourceAttr("data.deno_app_logs.errors", "logs.0.region", "gcp-us-east1"),
+				),
+			},
+		},
+	})
+}
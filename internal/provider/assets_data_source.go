@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/bmatcuk/doublestar/v4"
@@ -39,7 +40,11 @@ For how to use this data source with deno_deployment resource, please refer to t
 		Attributes: map[string]schema.Attribute{
 			"glob": schema.StringAttribute{
 				Required:    true,
-				Description: "The glob pattern to match the assets to be deployed. e.g. `**/*.ts`, `**/*.{ts,tsx,json}`",
+				Description: "The glob pattern to match the assets to be deployed. e.g. `**/*.ts`, `**/*.{ts,tsx,json}`. If `root_dir` is set, the pattern is evaluated relative to it.",
+			},
+			"root_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to the root directory of the application, relative to the current working directory. The glob pattern is evaluated relative to this directory, while the keys of the output are still relative to the current working directory. Set the same value to `root_dir` of `deno_deployment` to deploy the assets rooted at this directory.",
 			},
 			"output": schema.MapNestedAttribute{
 				Computed: true,
@@ -71,6 +76,7 @@ For how to use this data source with deno_deployment resource, please refer to t
 // assetsResourceModel maps the data source schema data.
 type assetsResourceModel struct {
 	AssetsGlob     types.String `tfsdk:"glob"`
+	RootDir        types.String `tfsdk:"root_dir"`
 	AssetsMetadata types.Map    `tfsdk:"output"`
 }
 
@@ -84,13 +90,32 @@ func (d *assetsResource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	paths, err := doublestar.FilepathGlob(config.AssetsGlob.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to Read Assets %s", config.AssetsGlob.ValueString()),
-			err.Error(),
-		)
-		return
+	var paths []string
+	if config.RootDir.IsNull() {
+		matches, err := doublestar.FilepathGlob(config.AssetsGlob.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Assets %s", config.AssetsGlob.ValueString()),
+				err.Error(),
+			)
+			return
+		}
+		paths = matches
+	} else {
+		// Evaluate the pattern inside the root directory, so that the root
+		// directory itself is never interpreted as a pattern.
+		rootDir := config.RootDir.ValueString()
+		matches, err := doublestar.Glob(os.DirFS(rootDir), config.AssetsGlob.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Assets %s in %s", config.AssetsGlob.ValueString(), rootDir),
+				err.Error(),
+			)
+			return
+		}
+		for _, m := range matches {
+			paths = append(paths, filepath.Join(rootDir, filepath.FromSlash(m)))
+		}
 	}
 
 	metadata := map[string]attr.Value{}
@@ -0,0 +1,138 @@
+package provider_test
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"terraform-provider-deno/client"
+	"terraform-provider-deno/internal/cassette"
+	"testing"
+)
+
+// The acceptance tests can record the HTTP traffic of the provider into
+// cassettes, and replay them later without network. The mode is selected with
+// the DENO_DEPLOY_CASSETTE environment variable:
+//
+//   - "record" runs the tests against the API as usual, and saves the
+//     exchanges of each test to testdata/cassettes/<test name>.json.
+//   - "replay" serves the responses from the cassettes, without starting the
+//     fake server or talking to the real API. A test is skipped if its
+//     cassette is missing, and fails if it sends a request that was not
+//     recorded.
+//
+// Replaying is deterministic only for tests whose configurations don't
+// change between runs, e.g. tests that don't generate random names, so only
+// the cassettes of such tests are committed.
+const (
+	cassetteModeRecord = "record"
+	cassetteModeReplay = "replay"
+
+	cassetteDir = "testdata/cassettes"
+)
+
+// Values of the environment variables in replay mode. They replace the
+// placeholders of the scrubbed values in the cassettes.
+const (
+	replayAPIHost        = "https://api.deno.test/v1"
+	replayToken          = "ddp_replay_token"
+	replayOrganizationID = "00000000-0000-0000-0000-000000000000"
+)
+
+// currentCassette is the recorder or the player of the running test, if any.
+// The acceptance tests don't run in parallel, so a single one is enough.
+var currentCassette client.HttpRequestDoer
+
+// testHTTPClient sends requests through the cassette of the running test, or
+// directHTTPClient if there is none.
+type testHTTPClient struct{}
+
+func (testHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if currentCassette != nil {
+		return currentCassette.Do(req)
+	}
+	return directHTTPClient{}.Do(req)
+}
+
+// directHTTPClient sends requests with http.DefaultClient. With the fake
+// server, the requests to the domains of the deployments are sent to it
+// instead. This happens beneath the recorder, so that the cassettes have the
+// original URLs of the domains, which are requested as is when replaying.
+type directHTTPClient struct{}
+
+func (directHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if fakeServer != nil && req.URL.Host != fakeServer.Listener.Addr().String() {
+		req = req.Clone(req.Context())
+		req.Host = req.URL.Host
+		req.URL.Scheme = "http"
+		req.URL.Host = fakeServer.Listener.Addr().String()
+	}
+	return http.DefaultClient.Do(req)
+}
+
+// getURL is like http.Get, but goes through the cassette of the running test.
+func getURL(url string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return testHTTPClient{}.Do(req)
+}
+
+func cassetteMode() string {
+	return os.Getenv("DENO_DEPLOY_CASSETTE")
+}
+
+func setReplayEnv() {
+	os.Setenv("DEPLOY_API_HOST", replayAPIHost)
+	os.Setenv("DENO_DEPLOY_TOKEN", replayToken)
+	os.Setenv("DENO_DEPLOY_ORGANIZATION_ID", replayOrganizationID)
+}
+
+// cassetteSecrets returns the values to scrub from the cassettes, keyed by the
+// names of their placeholders.
+func cassetteSecrets() map[string]string {
+	return map[string]string{
+		"DEPLOY_API_HOST":             os.Getenv("DEPLOY_API_HOST"),
+		"DENO_DEPLOY_TOKEN":           os.Getenv("DENO_DEPLOY_TOKEN"),
+		"DENO_DEPLOY_ORGANIZATION_ID": os.Getenv("DENO_DEPLOY_ORGANIZATION_ID"),
+	}
+}
+
+// useCassette records or replays the HTTP traffic of the test, depending on
+// the cassette mode.
+func useCassette(t *testing.T) {
+	path := filepath.Join(cassetteDir, filepath.FromSlash(t.Name())+".json")
+
+	switch mode := cassetteMode(); mode {
+	case "":
+		return
+	case cassetteModeRecord:
+		recorder := cassette.NewRecorder(path, directHTTPClient{}, cassetteSecrets())
+		currentCassette = recorder
+		t.Cleanup(func() {
+			currentCassette = nil
+			if err := recorder.Save(); err != nil {
+				t.Errorf("failed to save cassette: %s", err)
+			}
+		})
+	case cassetteModeReplay:
+		player, err := cassette.Load(path, cassetteSecrets())
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Skipf("no cassette is recorded at %s, record it with DENO_DEPLOY_CASSETTE=%s", path, cassetteModeRecord)
+		}
+		if err != nil {
+			t.Fatalf("failed to load cassette %s: %s", path, err)
+		}
+		currentCassette = player
+		t.Cleanup(func() {
+			currentCassette = nil
+			if n := player.Remaining(); n > 0 {
+				t.Errorf("%d recorded interactions were not replayed", n)
+			}
+		})
+	default:
+		t.Fatalf("unknown cassette mode %q, expected %q or %q", mode, cassetteModeRecord, cassetteModeReplay)
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"terraform-provider-deno/client"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		summary    string
+	}{
+		{
+			name:       "unauthorized",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"code":"unauthorized","message":"The access token is invalid."}`,
+			summary:    "Invalid Deno Deploy API Token",
+		},
+		{
+			name:       "not found",
+			statusCode: http.StatusNotFound,
+			body:       `{"code":"organizationNotFound","message":"The organization was not found."}`,
+			summary:    "Inaccessible Deno Deploy Organization",
+		},
+		{
+			name:       "not found without error code",
+			statusCode: http.StatusNotFound,
+			body:       "Not Found",
+			summary:    "Inaccessible Deno Deploy Organization",
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			body:       `{"code":"forbidden","message":"Access denied."}`,
+			summary:    "Inaccessible Deno Deploy Organization",
+		},
+		{
+			name:       "server error",
+			statusCode: http.StatusInternalServerError,
+			body:       `{"code":"internalServerError","message":"Something went wrong."}`,
+			summary:    "Unable to Validate Deno Deploy Credentials",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set(client.X_DENO_RAY, "trace-id")
+				w.WriteHeader(test.statusCode)
+				_, _ = io.WriteString(w, test.body)
+			}))
+			defer server.Close()
+
+			c, err := client.NewClientWithResponses(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diags := validateCredentials(context.Background(), c, server.URL, uuid.New())
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %v", diags)
+			}
+			if diags[0].Summary() != test.summary {
+				t.Errorf("expected summary %q, got %q", test.summary, diags[0].Summary())
+			}
+			// The detail of the API error is included, such as the trace ID
+			if !strings.Contains(diags[0].Detail(), "trace-id") {
+				t.Errorf("expected the detail to include the trace ID, got %q", diags[0].Detail())
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &deploymentDataSource{}
+	_ datasource.DataSourceWithConfigure = &deploymentDataSource{}
+)
+
+// NewDeploymentDataSource is a helper function to simplify the provider implementation.
+func NewDeploymentDataSource() datasource.DataSource {
+	return &deploymentDataSource{}
+}
+
+// deploymentDataSource is the data source implementation.
+type deploymentDataSource struct {
+	client client.ClientWithResponsesInterface
+}
+
+// deploymentDataSourceModel maps the data source schema data.
+type deploymentDataSourceModel struct {
+	DeploymentID     types.String `tfsdk:"deployment_id"`
+	IncludeBuildLogs types.Bool   `tfsdk:"include_build_logs"`
+	ProjectID        types.String `tfsdk:"project_id"`
+	Status           types.String `tfsdk:"status"`
+	Domains          types.Set    `tfsdk:"domains"`
+	BuildLogs        types.List   `tfsdk:"build_logs"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *deploymentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployment"
+}
+
+// Schema defines the schema for the data source.
+func (d *deploymentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for an existing deployment.
+
+This is useful for referring to a deployment created outside of Terraform, e.g. by deployctl in another pipeline.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"deployment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the deployment.",
+			},
+			"include_build_logs": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to fetch the build logs of the deployment into `build_logs`. Defaults to false.",
+			},
+			"project_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the project that the deployment belongs to.",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "The status of the deployment. It can be either `pending`, `success` or `failed`.",
+			},
+			"domains": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The domains that the deployment is accessible with.",
+			},
+			"build_logs": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The build logs of the deployment. It is only populated when `include_build_logs` is true.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"level": schema.StringAttribute{
+							Computed:    true,
+							Description: "The level of the log line, such as `info` or `error`.",
+						},
+						"message": schema.StringAttribute{
+							Computed:    true,
+							Description: "The message of the log line.",
+						},
+					},
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the deployment was created, formatted in RFC3339.",
+				MarkdownDescription: "The time the deployment was created, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the deployment was last updated, formatted in RFC3339.",
+				MarkdownDescription: "The time the deployment was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *deploymentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state deploymentDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deploymentID := state.DeploymentID.ValueString()
+	deployment, err := d.client.GetDeploymentWithResponse(ctx, deploymentID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Deployment %s", deploymentID),
+			err.Error(),
+		)
+		return
+	}
+	if err := client.CheckResponse(deployment); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Deployment %s", deploymentID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	domains := []string{}
+	if deployment.JSON200.Domains != nil {
+		domains = *deployment.JSON200.Domains
+	}
+	domainsSet, diags := types.SetValueFrom(ctx, types.StringType, domains)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ProjectID = types.StringValue(deployment.JSON200.ProjectId.String())
+	state.Status = types.StringValue(string(deployment.JSON200.Status))
+	state.Domains = domainsSet
+	state.CreatedAt = types.StringValue(deployment.JSON200.CreatedAt.Format(time.RFC3339))
+	state.UpdatedAt = types.StringValue(deployment.JSON200.UpdatedAt.Format(time.RFC3339))
+	state.BuildLogs = types.ListNull(buildLogType)
+
+	if state.IncludeBuildLogs.ValueBool() {
+		// Ask for a JSON array rather than a NDJSON stream, since the build has
+		// usually completed
+		acceptJSON := func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Accept", "application/json")
+			return nil
+		}
+		logs, err := d.client.GetBuildLogsWithResponse(ctx, deploymentID, acceptJSON)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Build Logs of Deployment %s", deploymentID),
+				err.Error(),
+			)
+			return
+		}
+		if err := client.CheckResponse(logs); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Build Logs of Deployment %s", deploymentID),
+				apiErrorDetail(err),
+			)
+			return
+		}
+
+		var entries []client.BuildLogsResponseEntry
+		if logs.JSON200 != nil {
+			entries = *logs.JSON200
+		}
+		buildLogs, diags := convertToBuildLogsList(entries)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.BuildLogs = buildLogs
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *deploymentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+}
@@ -0,0 +1,59 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeploymentDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "deno_project" "test" {}
+
+					data "deno_assets" "test" {
+						glob = "testdata/single-file/main.ts"
+					}
+
+					resource "deno_deployment" "test" {
+						project_id = deno_project.test.id
+						entry_point_url = "testdata/single-file/main.ts"
+						assets = data.deno_assets.test.output
+						env_vars = {}
+					}
+
+					data "deno_deployment" "test" {
+						deployment_id = deno_deployment.test.deployment_id
+					}
+
+					data "deno_deployment" "with_logs" {
+						deployment_id      = deno_deployment.test.deployment_id
+						include_build_logs = true
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.deno_deployment.test", "project_id", "deno_project.test", "id"),
+					resource.TestCheckResourceAttrPair("data.deno_deployment.test", "status", "deno_deployment.test", "status"),
+					resource.TestCheckResourceAttrPair("data.deno_deployment.test", "domains.#", "deno_deployment.test", "domains.#"),
+					resource.TestCheckResourceAttrPair("data.deno_deployment.test", "created_at", "deno_deployment.test", "created_at"),
+					resource.TestCheckNoResourceAttr("data.deno_deployment.test", "build_logs"),
+					resource.TestCheckResourceAttrSet("data.deno_deployment.with_logs", "build_logs.0.message"),
+				),
+			},
+			{
+				Config: `
+					data "deno_deployment" "test" {
+						deployment_id = "doesnotexist"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to Read Deployment`),
+			},
+		},
+	})
+}
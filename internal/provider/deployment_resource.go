@@ -1,10 +1,11 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,16 +17,29 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &deploymentResource{}
-	_ resource.ResourceWithConfigure = &deploymentResource{}
+	_ resource.Resource                   = &deploymentResource{}
+	_ resource.ResourceWithConfigure      = &deploymentResource{}
+	_ resource.ResourceWithValidateConfig = &deploymentResource{}
+	_ resource.ResourceWithImportState    = &deploymentResource{}
+)
+
+const (
+	// DEFAULT_DEPLOYMENT_TIMEOUT is the default time to wait for a deployment
+	// to reach a final status.
+	DEFAULT_DEPLOYMENT_TIMEOUT = 10 * time.Minute
+	// DEPLOYMENT_POLLING_INTERVAL is the interval between status checks while
+	// waiting for a deployment to complete.
+	DEPLOYMENT_POLLING_INTERVAL = 2 * time.Second
 )
 
 // NewDeploymentResource is a helper function to simplify the provider implementation.
@@ -36,6 +50,7 @@ func NewDeploymentResource() resource.Resource {
 // deploymentResource is the resource implementation.
 type deploymentResource struct {
 	client         client.ClientWithResponsesInterface
+	rawClient      client.ClientInterface
 	organizationID uuid.UUID
 }
 
@@ -43,6 +58,7 @@ type deploymentResource struct {
 type deploymentResourceModel struct {
 	DeploymentID    types.String          `tfsdk:"deployment_id"`
 	ProjectID       types.String          `tfsdk:"project_id"`
+	RootDir         types.String          `tfsdk:"root_dir"`
 	Status          types.String          `tfsdk:"status"`
 	Domains         types.Set             `tfsdk:"domains"`
 	EntryPointURL   types.String          `tfsdk:"entry_point_url"`
@@ -52,6 +68,8 @@ type deploymentResourceModel struct {
 	Assets          types.Map             `tfsdk:"assets"`
 	UploadedAssets  types.Map             `tfsdk:"uploaded_assets"`
 	EnvVars         types.Map             `tfsdk:"env_vars"`
+	SecretEnvVars   types.Map             `tfsdk:"secret_env_vars"`
+	BuildLogs       types.List            `tfsdk:"build_logs"`
 	CreatedAt       types.String          `tfsdk:"created_at"`
 	UpdatedAt       types.String          `tfsdk:"updated_at"`
 	Timeouts        timeouts.Value        `tfsdk:"timeouts"`
@@ -87,6 +105,10 @@ A deployment belongs to a project, is an immutable, invokable snapshot of the pr
 				Required:    true,
 				Description: "The project ID that this deployment belongs to.",
 			},
+			"root_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to the root directory of the application, relative to the current working directory. The keys of `assets`, `entry_point_url`, `import_map_url`, `lock_file_url` and absolute symlink targets are resolved relative to this directory, so that the deployment is rooted at it. Assets outside of this directory are rejected. Defaults to the current working directory.",
+			},
 			"status": schema.StringAttribute{
 				Computed:    true,
 				Description: `The status of the deployment, indicating whether the deployment succeeded or not. It can be "failed", "pending", or "success"`,
@@ -152,7 +174,7 @@ A deployment belongs to a project, is an immutable, invokable snapshot of the pr
 			},
 			"uploaded_assets": schema.MapNestedAttribute{
 				Computed:    true,
-				Description: "The assets that have been uploaded in previous deployments, keyed with hash of the content. This is inteneded to be used to avoid uploading the same assets multiple times.",
+				Description: "The assets that have been uploaded in previous deployments, keyed with hash of the content. This is inteneded to be used to avoid uploading the same assets multiple times. Assets removed from a deployment are kept, so that they are not uploaded again once restored.",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"path": schema.StringAttribute{
@@ -172,6 +194,28 @@ A deployment belongs to a project, is an immutable, invokable snapshot of the pr
 				ElementType: types.StringType,
 				Description: "The environment variables to be set in the runtime environment of the deployment.",
 			},
+			"secret_env_vars": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				Description: "The environment variables holding secrets, such as passwords or API keys, to be set in the runtime environment of the deployment. These are merged with `env_vars`, but hidden from the plan output and the provider logs. Note that the values are still stored in the Terraform state. Keys must not overlap with the ones in `env_vars`.",
+			},
+			"build_logs": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The build logs of the deployment, which are also streamed to the Terraform log while the build is running.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"level": schema.StringAttribute{
+							Computed:    true,
+							Description: "The level of the log line, such as `info` or `error`.",
+						},
+						"message": schema.StringAttribute{
+							Computed:    true,
+							Description: "The message of the log line.",
+						},
+					},
+				},
+			},
 			"created_at": schema.StringAttribute{
 				Computed:            true,
 				Description:         "The time the deployment was created, formmatting in RFC3339.",
@@ -184,36 +228,82 @@ A deployment belongs to a project, is an immutable, invokable snapshot of the pr
 			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
+				Update: true,
 			}),
 		},
 	}
 }
 
-func prepareAssetsForUpload(ctx context.Context, plannedAssets types.Map) (client.Assets, diag.Diagnostic) {
-	rootPath := "."
+// uploadedAssetModel maps the uploaded asset schema data.
+type uploadedAssetModel struct {
+	Path      types.String `tfsdk:"path"`
+	GitSha1   types.String `tfsdk:"git_sha1"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+var uploadedAssetType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"path":       types.StringType,
+		"git_sha1":   types.StringType,
+		"updated_at": types.StringType,
+	},
+}
+
+// mentionsHashOnlyAsset returns true if the response body mentions the path
+// or the hash of any of the assets sent by hash only.
+func mentionsHashOnlyAsset(body []byte, assets client.Assets) bool {
+	for path, asset := range assets {
+		fileAsset, err := asset.AsFileAsset()
+		if err != nil || fileAsset.Kind != client.FileAssetKindFile {
+			continue
+		}
+		hashOnly, err := fileAsset.AsFileAsset1()
+		if err != nil || hashOnly.GitSha1 == "" {
+			continue
+		}
+		if bytes.Contains(body, []byte(path)) || bytes.Contains(body, []byte(hashOnly.GitSha1)) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareAssetsForUpload builds the assets to be sent to the API from the
+// planned assets, whose keys are resolved relative to rootDir. Files whose
+// hash is found in uploadedAssets are sent as hash-only assets so that their
+// content is not uploaded again. In addition to the assets, it returns the
+// file assets composing the deployment, keyed with the hash of the content.
+func prepareAssetsForUpload(ctx context.Context, rootDir string, plannedAssets types.Map, uploadedAssets map[string]uploadedAssetModel) (client.Assets, map[string]uploadedAssetModel, diag.Diagnostic) {
 	assets := make(client.Assets)
+	deployedFiles := make(map[string]uploadedAssetModel)
 
 	for path, metadata := range plannedAssets.Elements() {
 		obj, ok := metadata.(types.Object)
 		if !ok {
-			return nil, diag.NewErrorDiagnostic(
+			return nil, nil, diag.NewErrorDiagnostic(
 				"Unable to Create Deployment",
 				fmt.Sprintf("Could not parse asset metadata for %s", path),
 			)
 		}
 		metadataValues := obj.Attributes()
 
-		relpath, err := filepath.Rel(rootPath, path)
+		relpath, err := relativeToRoot(rootDir, path)
 		if err != nil {
-			return nil, diag.NewErrorDiagnostic(
+			return nil, nil, diag.NewErrorDiagnostic(
 				"Unable to Create Deployment",
-				fmt.Sprintf("Could not get file path relative to the current directory. target: %s", path),
+				fmt.Sprintf("Could not get file path relative to the root directory %s. target: %s", rootDir, path),
+			)
+		}
+		if rootDir != "." && isOutsideRoot(relpath) {
+			return nil, nil, diag.NewErrorDiagnostic(
+				"Unable to Create Deployment",
+				fmt.Sprintf("Asset %s is outside of the root directory %s", path, rootDir),
 			)
 		}
 
 		kind, ok := metadataValues["kind"].(types.String)
 		if !ok {
-			return nil, diag.NewErrorDiagnostic(
+			return nil, nil, diag.NewErrorDiagnostic(
 				"Unable to Create Deployment",
 				fmt.Sprintf("Could not parse asset kind for %s. Expected string, but got %s", path, metadataValues["kind"].Type(ctx)),
 			)
@@ -223,59 +313,89 @@ func prepareAssetsForUpload(ctx context.Context, plannedAssets types.Map) (clien
 		case "file":
 			b, err := os.ReadFile(path)
 			if err != nil {
-				return nil, diag.NewErrorDiagnostic(
+				return nil, nil, diag.NewErrorDiagnostic(
 					"Unable to Create Deployment",
 					fmt.Sprintf("Could not read file content for %s", path),
 				)
 			}
+			gitSha1 := calculateGitSha1(b)
+
 			var fileAsset client.FileAsset
-			var fileContent client.FileAsset0
-			if utf8.Valid(b) {
-				enc := client.Utf8
-				fileContent = client.FileAsset0{
-					Content:  string(b),
-					Encoding: &enc,
+			if _, ok := uploadedAssets[gitSha1]; ok {
+				// The content has already been uploaded; only the hash is needed.
+				err = fileAsset.FromFileAsset1(client.FileAsset1{
+					GitSha1: gitSha1,
+				})
+				if err != nil {
+					return nil, nil, diag.NewErrorDiagnostic(
+						"Unable to Create Deployment",
+						fmt.Sprintf("Internal error happened for %s on FromFileAsset1", path),
+					)
 				}
 			} else {
-				enc := client.Base64
-				fileContent = client.FileAsset0{
-					Content:  base64.StdEncoding.EncodeToString(b),
-					Encoding: &enc,
+				var fileContent client.FileAsset0
+				if utf8.Valid(b) {
+					enc := client.Utf8
+					fileContent = client.FileAsset0{
+						Content:  string(b),
+						Encoding: &enc,
+					}
+				} else {
+					enc := client.Base64
+					fileContent = client.FileAsset0{
+						Content:  base64.StdEncoding.EncodeToString(b),
+						Encoding: &enc,
+					}
 				}
-			}
 
-			err = fileAsset.FromFileAsset0(fileContent)
-			if err != nil {
-				return nil, diag.NewErrorDiagnostic(
-					"Unable to Create Deployment",
-					fmt.Sprintf("Internal error happened for %s on FromFileAsset0", path),
-				)
+				err = fileAsset.FromFileAsset0(fileContent)
+				if err != nil {
+					return nil, nil, diag.NewErrorDiagnostic(
+						"Unable to Create Deployment",
+						fmt.Sprintf("Internal error happened for %s on FromFileAsset0", path),
+					)
+				}
 			}
 			var asset client.Asset
 			err = asset.FromFileAsset(fileAsset)
 			if err != nil {
-				return nil, diag.NewErrorDiagnostic(
+				return nil, nil, diag.NewErrorDiagnostic(
 					"Unable to Create Deployment",
 					fmt.Sprintf("Internal error happened for %s on FromFileAsset", path),
 				)
 			}
 
 			assets[encodePath(relpath)] = asset
+
+			updatedAt, ok := metadataValues["updated_at"].(types.String)
+			if !ok {
+				updatedAt = types.StringNull()
+			}
+			deployedFiles[gitSha1] = uploadedAssetModel{
+				Path:      types.StringValue(relpath),
+				GitSha1:   types.StringValue(gitSha1),
+				UpdatedAt: updatedAt,
+			}
 		case "symlink":
 			targetPath, ok := metadataValues["target"].(types.String)
 			if !ok {
-				return nil, diag.NewErrorDiagnostic(
+				return nil, nil, diag.NewErrorDiagnostic(
 					"Unable to Create Deployment",
 					fmt.Sprintf("Could not parse target path for %s. Expected string, but got %s", path, metadataValues["target"].Type(ctx)),
 				)
 			}
 
-			targetRel, err := filepath.Rel(rootPath, targetPath.ValueString())
-			if err != nil {
-				return nil, diag.NewErrorDiagnostic(
-					"Unable to Create Deployment",
-					fmt.Sprintf("Could not get file path relative to the current directory. target: %s", targetPath),
-				)
+			// A relative target is relative to the symlink itself, so only an
+			// absolute one needs to be resolved against the root directory.
+			targetRel := filepath.ToSlash(filepath.Clean(targetPath.ValueString()))
+			if filepath.IsAbs(targetPath.ValueString()) {
+				targetRel, err = relativeToRoot(rootDir, targetPath.ValueString())
+				if err != nil {
+					return nil, nil, diag.NewErrorDiagnostic(
+						"Unable to Create Deployment",
+						fmt.Sprintf("Could not get file path relative to the root directory %s. target: %s", rootDir, targetPath),
+					)
+				}
 			}
 			symlinkAsset := client.SymlinkAsset{
 				Target: encodePath(targetRel),
@@ -285,7 +405,7 @@ func prepareAssetsForUpload(ctx context.Context, plannedAssets types.Map) (clien
 			var asset client.Asset
 			err = asset.FromSymlinkAsset(symlinkAsset)
 			if err != nil {
-				return nil, diag.NewErrorDiagnostic(
+				return nil, nil, diag.NewErrorDiagnostic(
 					"Unable to Create Deployment",
 					fmt.Sprintf("Internal error happened for %s on FromSymlinkAsset", path),
 				)
@@ -293,7 +413,7 @@ func prepareAssetsForUpload(ctx context.Context, plannedAssets types.Map) (clien
 
 			assets[encodePath(relpath)] = asset
 		default:
-			return nil, diag.NewErrorDiagnostic(
+			return nil, nil, diag.NewErrorDiagnostic(
 				"Unable to Create Deployment",
 				fmt.Sprintf("Invalid asset kind %s is found for %s. Valid kinds are `file`, `symlink`", kind.ValueString(), path),
 			)
@@ -301,13 +421,13 @@ func prepareAssetsForUpload(ctx context.Context, plannedAssets types.Map) (clien
 	}
 
 	if len(assets) == 0 {
-		return nil, diag.NewErrorDiagnostic(
+		return nil, nil, diag.NewErrorDiagnostic(
 			"Unable to Create Deployment",
 			"No assets are found. At least one asset is required.",
 		)
 	}
 
-	return assets, nil
+	return assets, deployedFiles, nil
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -320,8 +440,14 @@ func (r *deploymentResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Create(ctx, DEFAULT_DEPLOYMENT_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Do deployment
-	diags = r.doDeployment(ctx, &plan)
+	diags = r.doDeployment(ctx, &plan, nil, timeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -355,10 +481,18 @@ func (r *deploymentResource) Read(ctx context.Context, req resource.ReadRequest,
 		)
 		return
 	}
-	if client.RespIsError(deployment) {
+	if err := client.CheckResponse(deployment); err != nil {
+		if client.IsNotFound(err) {
+			// The deployment has been deleted outside of Terraform
+			tflog.Warn(ctx, "Deployment not found, removing it from the state", map[string]any{
+				"deployment_id": deploymentID,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			"Failed to Get Deployment Details",
-			fmt.Sprintf("Deployment ID: %s, Error: %s", deploymentID, client.APIErrorDetail(deployment.HTTPResponse, deployment.Body)),
+			fmt.Sprintf("Deployment ID: %s, Error: %s", deploymentID, apiErrorDetail(err)),
 		)
 		return
 	}
@@ -396,8 +530,32 @@ func (r *deploymentResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, DEFAULT_DEPLOYMENT_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Retrieve values from state to find out the assets uploaded previously
+	var state deploymentResourceModel
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Uploaded assets can only be reused within the same project
+	var uploadedAssets map[string]uploadedAssetModel
+	if state.ProjectID.Equal(plan.ProjectID) && !state.UploadedAssets.IsNull() && !state.UploadedAssets.IsUnknown() {
+		diags = state.UploadedAssets.ElementsAs(ctx, &uploadedAssets, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Do deployment
-	diags = r.doDeployment(ctx, &plan)
+	diags = r.doDeployment(ctx, &plan, uploadedAssets, timeout)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -416,6 +574,75 @@ func (r *deploymentResource) Delete(ctx context.Context, req resource.DeleteRequ
 	// noop
 }
 
+// ValidateConfig validates the resource configuration.
+func (r *deploymentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config deploymentResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keys can't be checked until both maps are known
+	if config.EnvVars.IsUnknown() || config.SecretEnvVars.IsUnknown() {
+		return
+	}
+
+	envVars := config.EnvVars.Elements()
+	for key := range config.SecretEnvVars.Elements() {
+		if _, ok := envVars[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("secret_env_vars").AtMapKey(key),
+				"Conflicting Environment Variable",
+				fmt.Sprintf("The environment variable %s is defined in both env_vars and secret_env_vars. Each key must be defined in only one of them.", key),
+			)
+		}
+	}
+}
+
+// ImportState imports the existing resource into Terraform. The import ID is
+// the ID of the deployment.
+//
+// Only the attributes returned by the API are imported. The assets and
+// environment variables can't be retrieved, so the next apply creates a new
+// deployment from the configuration.
+func (r *deploymentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	deploymentID := req.ID
+
+	deployment, err := r.client.GetDeploymentWithResponse(ctx, deploymentID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Import Deployment %s", deploymentID),
+			err.Error(),
+		)
+		return
+	}
+	if err := client.CheckResponse(deployment); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Import Deployment %s", deploymentID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	var domains []string
+	if deployment.JSON200.Domains != nil {
+		domains = *deployment.JSON200.Domains
+	}
+	domainSet, diags := types.SetValueFrom(ctx, types.StringType, domains)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deployment_id"), deployment.JSON200.Id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), deployment.JSON200.ProjectId.String())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("status"), string(deployment.JSON200.Status))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domains"), domainSet)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created_at"), deployment.JSON200.CreatedAt.Format(time.RFC3339))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("updated_at"), deployment.JSON200.UpdatedAt.Format(time.RFC3339))...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *deploymentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -434,12 +661,16 @@ func (r *deploymentResource) Configure(_ context.Context, req resource.Configure
 	}
 
 	r.client = providerData.client
+	r.rawClient = providerData.rawClient
 	r.organizationID = providerData.organizationID
 }
 
-func (r *deploymentResource) doDeployment(ctx context.Context, plan *deploymentResourceModel) diag.Diagnostics {
+func (r *deploymentResource) doDeployment(ctx context.Context, plan *deploymentResourceModel, uploadedAssets map[string]uploadedAssetModel, timeout time.Duration) diag.Diagnostics {
 	accumulatedDiags := diag.Diagnostics{}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	projectID, err := uuid.Parse(plan.ProjectID.ValueString())
 	if err != nil {
 		accumulatedDiags.AddError(
@@ -449,7 +680,12 @@ func (r *deploymentResource) doDeployment(ctx context.Context, plan *deploymentR
 		return accumulatedDiags
 	}
 
-	assets, diag := prepareAssetsForUpload(ctx, plan.Assets)
+	rootDir := "."
+	if !plan.RootDir.IsNull() {
+		rootDir = plan.RootDir.ValueString()
+	}
+
+	assets, deployedFiles, diag := prepareAssetsForUpload(ctx, rootDir, plan.Assets, uploadedAssets)
 	accumulatedDiags.Append(diag)
 	if accumulatedDiags.HasError() {
 		return accumulatedDiags
@@ -462,6 +698,24 @@ func (r *deploymentResource) doDeployment(ctx context.Context, plan *deploymentR
 		return accumulatedDiags
 	}
 
+	var secretEnvVars map[string]string
+	diags = plan.SecretEnvVars.ElementsAs(ctx, &secretEnvVars, true)
+	accumulatedDiags.Append(diags...)
+	if accumulatedDiags.HasError() {
+		return accumulatedDiags
+	}
+	if len(secretEnvVars) > 0 && envVars == nil {
+		envVars = make(map[string]string, len(secretEnvVars))
+	}
+	for k, v := range secretEnvVars {
+		// Make sure the secret values never show up in the logs, including the
+		// build logs streamed below.
+		if v != "" {
+			ctx = tflog.MaskLogStrings(ctx, v)
+		}
+		envVars[k] = v
+	}
+
 	var compilerOptions *client.CompilerOptions
 	if plan.CompilerOptions != nil {
 		compilerOptions = &client.CompilerOptions{
@@ -471,14 +725,61 @@ func (r *deploymentResource) doDeployment(ctx context.Context, plan *deploymentR
 			JsxImportSource:    plan.CompilerOptions.JSXImportSource.ValueStringPointer(),
 		}
 	}
-	res, err := r.client.CreateDeploymentWithResponse(ctx, projectID, client.CreateDeploymentRequest{
+	entryPointURL, diag := resolveURLToRoot(rootDir, "entry_point_url", plan.EntryPointURL.ValueString())
+	accumulatedDiags.Append(diag)
+	if accumulatedDiags.HasError() {
+		return accumulatedDiags
+	}
+
+	var importMapURL *string
+	if !plan.ImportMapURL.IsNull() {
+		u, diag := resolveURLToRoot(rootDir, "import_map_url", plan.ImportMapURL.ValueString())
+		accumulatedDiags.Append(diag)
+		if accumulatedDiags.HasError() {
+			return accumulatedDiags
+		}
+		importMapURL = &u
+	}
+
+	var lockFileURL *string
+	if !plan.LockFileURL.IsNull() {
+		u, diag := resolveURLToRoot(rootDir, "lock_file_url", plan.LockFileURL.ValueString())
+		accumulatedDiags.Append(diag)
+		if accumulatedDiags.HasError() {
+			return accumulatedDiags
+		}
+		lockFileURL = &u
+	}
+
+	deploymentRequest := client.CreateDeploymentRequest{
 		Assets:          assets,
 		CompilerOptions: compilerOptions,
-		EntryPointUrl:   plan.EntryPointURL.ValueString(),
+		EntryPointUrl:   entryPointURL,
 		EnvVars:         envVars,
-		ImportMapUrl:    plan.ImportMapURL.ValueStringPointer(),
-		LockFileUrl:     plan.LockFileURL.ValueStringPointer(),
-	})
+		ImportMapUrl:    importMapURL,
+		LockFileUrl:     lockFileURL,
+	}
+	res, err := r.client.CreateDeploymentWithResponse(ctx, projectID, deploymentRequest)
+	reuploaded := false
+	if err == nil && res.JSON400 != nil && mentionsHashOnlyAsset(res.Body, assets) {
+		// Some of the hashes are probably unknown to the API, in which case
+		// we fall back to uploading the full content of every file. The API
+		// doesn't document an error code for this, so any 400 naming one of
+		// the hash-only assets is retried once. Other errors are reported as
+		// they are.
+		reuploaded = true
+		tflog.Warn(ctx, "Deployment with hash-only assets was rejected; retrying with full content", map[string]any{
+			"code":    res.JSON400.Code,
+			"message": res.JSON400.Message,
+		})
+		assets, _, diag = prepareAssetsForUpload(ctx, rootDir, plan.Assets, nil)
+		accumulatedDiags.Append(diag)
+		if accumulatedDiags.HasError() {
+			return accumulatedDiags
+		}
+		deploymentRequest.Assets = assets
+		res, err = r.client.CreateDeploymentWithResponse(ctx, projectID, deploymentRequest)
+	}
 	if err != nil {
 		accumulatedDiags.AddError(
 			fmt.Sprintf("Unable to Create Deployment for Project %s", plan.ProjectID),
@@ -486,44 +787,44 @@ func (r *deploymentResource) doDeployment(ctx context.Context, plan *deploymentR
 		)
 		return accumulatedDiags
 	}
-	if client.RespIsError(res) {
+	if err := client.CheckResponse(res); err != nil {
 		accumulatedDiags.AddError(
 			fmt.Sprintf("Unable to Create Deployment for Project %s", plan.ProjectID),
-			client.APIErrorDetail(res.HTTPResponse, res.Body),
+			apiErrorDetail(err),
 		)
 		return accumulatedDiags
 	}
 
 	deploymentID := res.JSON200.Id
 
-	buildLogs, err := r.client.GetBuildLogsWithResponse(ctx, deploymentID, func(ctx context.Context, req *http.Request) error {
-		req.Header.Add("Accept", "application/json")
-		return nil
+	// Stream build logs while the build is running
+	var buildLogEntries []client.BuildLogsResponseEntry
+	err = client.StreamBuildLogs(ctx, r.rawClient, deploymentID, func(entry client.BuildLogsResponseEntry) {
+		buildLogEntries = append(buildLogEntries, entry)
+		logBuildLogEntry(ctx, deploymentID, entry)
 	})
-	if err != nil {
-		accumulatedDiags.AddError(
-			"Deployment Initiated, but Failed to Get Build Logs",
-			fmt.Sprintf("Deployment ID: %s, Error: %s", deploymentID, err.Error()),
-		)
-		return accumulatedDiags
-	}
-	if client.RespIsError(buildLogs) {
-		accumulatedDiags.AddError(
-			"Deployment Initiated, but Failed to Get Build Logs",
-			fmt.Sprintf("Deployment ID: %s, Error: %s", deploymentID, client.APIErrorDetail(buildLogs.HTTPResponse, buildLogs.Body)),
-		)
-		return accumulatedDiags
-	}
 
-	logs := make([]string, len(*buildLogs.JSON200))
-	for i, logline := range *buildLogs.JSON200 {
+	logs := make([]string, len(buildLogEntries))
+	for i, logline := range buildLogEntries {
 		logs[i] = fmt.Sprintf("[%s] %s", logline.Level, logline.Message)
 	}
 
-	deployment, err := r.client.GetDeploymentWithResponse(ctx, deploymentID)
 	if err != nil {
+		// The build may outlive the timeout while the logs are streamed
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			accumulatedDiags.AddError(
+				"Deployment Initiated, but Timed Out Waiting for Completion",
+				fmt.Sprintf(`Deployment ID: %s
+Timed out after %s
+
+Build logs:
+%s
+`, deploymentID, timeout, strings.Join(logs, "\n")),
+			)
+			return accumulatedDiags
+		}
 		accumulatedDiags.AddError(
-			"Deployment Initiated, but Failed to Get Deployment Details",
+			"Deployment Initiated, but Failed to Get Build Logs",
 			fmt.Sprintf(`Deployment ID: %s
 Error: %s
 
@@ -533,21 +834,22 @@ Build logs:
 		)
 		return accumulatedDiags
 	}
-	if client.RespIsError(deployment) {
-		accumulatedDiags.AddError(
-			"Deployment Initiated, but Failed to Get Deployment Details",
-			fmt.Sprintf(`Deployment ID: %s
-Error: %s
+
+	// Wait for the deployment to reach a final status
+	deployment, diags := r.waitForDeployment(ctx, deploymentID, timeout)
+	if diags.HasError() {
+		for _, d := range diags.Errors() {
+			accumulatedDiags.AddError(d.Summary(), fmt.Sprintf(`%s
 
 Build logs:
 %s
-`, deploymentID, client.APIErrorDetail(deployment.HTTPResponse, deployment.Body), strings.Join(logs, "\n")),
-		)
+`, d.Detail(), strings.Join(logs, "\n")))
+		}
 		return accumulatedDiags
 	}
 
 	// Ensure the deployment has succeeded
-	if deployment.JSON200.Status != client.DeploymentStatusSuccess {
+	if deployment.Status != client.DeploymentStatusSuccess {
 		accumulatedDiags.AddError(
 			"Deployment Failed",
 			fmt.Sprintf(`Deployment ID: %s
@@ -555,16 +857,16 @@ Status: %s
 
 Build logs:
 %s
-`, deploymentID, deployment.JSON200.Status, strings.Join(logs, "\n")),
+`, deploymentID, deployment.Status, strings.Join(logs, "\n")),
 		)
 		return accumulatedDiags
 	}
 
 	// Deployment succeeded
-	plan.DeploymentID = types.StringValue(deployment.JSON200.Id)
-	plan.Status = types.StringValue(string(deployment.JSON200.Status))
-	domainElems := make([]attr.Value, len(*deployment.JSON200.Domains))
-	for i, d := range *deployment.JSON200.Domains {
+	plan.DeploymentID = types.StringValue(deployment.Id)
+	plan.Status = types.StringValue(string(deployment.Status))
+	domainElems := make([]attr.Value, len(*deployment.Domains))
+	for i, d := range *deployment.Domains {
 		domainElems[i] = types.StringValue(d)
 	}
 	domainSet, diags := types.SetValue(basetypes.StringType{}, domainElems)
@@ -574,22 +876,170 @@ Build logs:
 	}
 	plan.Domains = domainSet
 
-	// TODO: we haven't implemented the logic to avoid duplicate uploads
-	uploadedAssets, diags := types.MapValue(types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"path":       types.StringType,
-			"git_sha1":   types.StringType,
-			"updated_at": types.StringType,
-		},
-	}, map[string]attr.Value{})
+	// All the files composing this deployment have been uploaded now, so the
+	// next deployment doesn't need to upload them again. The files uploaded
+	// previously are kept too, so that a file removed and restored later is
+	// not uploaded again, unless the API has just proven to have forgotten
+	// some of them.
+	if !reuploaded {
+		for hash, asset := range uploadedAssets {
+			if _, ok := deployedFiles[hash]; !ok {
+				deployedFiles[hash] = asset
+			}
+		}
+	}
+	uploadedAssetsValue, diags := types.MapValueFrom(ctx, uploadedAssetType, deployedFiles)
+	accumulatedDiags.Append(diags...)
+	if accumulatedDiags.HasError() {
+		return accumulatedDiags
+	}
+	plan.UploadedAssets = uploadedAssetsValue
+
+	buildLogs, diags := convertToBuildLogsList(buildLogEntries)
 	accumulatedDiags.Append(diags...)
 	if accumulatedDiags.HasError() {
 		return accumulatedDiags
 	}
-	plan.UploadedAssets = uploadedAssets
+	plan.BuildLogs = buildLogs
 
-	plan.CreatedAt = types.StringValue(deployment.JSON200.CreatedAt.Format(time.RFC3339))
-	plan.UpdatedAt = types.StringValue(deployment.JSON200.UpdatedAt.Format(time.RFC3339))
+	plan.CreatedAt = types.StringValue(deployment.CreatedAt.Format(time.RFC3339))
+	plan.UpdatedAt = types.StringValue(deployment.UpdatedAt.Format(time.RFC3339))
 
 	return accumulatedDiags
 }
+
+// logBuildLogEntry emits a build log entry to the Terraform log at the level
+// matching the entry.
+func logBuildLogEntry(ctx context.Context, deploymentID string, entry client.BuildLogsResponseEntry) {
+	fields := map[string]any{
+		"deployment_id": deploymentID,
+	}
+	switch strings.ToLower(entry.Level) {
+	case "error":
+		tflog.Error(ctx, entry.Message, fields)
+	case "warn", "warning":
+		tflog.Warn(ctx, entry.Message, fields)
+	case "debug":
+		tflog.Debug(ctx, entry.Message, fields)
+	default:
+		tflog.Info(ctx, entry.Message, fields)
+	}
+}
+
+var buildLogType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"level":   types.StringType,
+		"message": types.StringType,
+	},
+}
+
+func convertToBuildLogsList(entries []client.BuildLogsResponseEntry) (types.List, diag.Diagnostics) {
+	logs := make([]attr.Value, len(entries))
+	for i, entry := range entries {
+		objectValue, diags := types.ObjectValue(buildLogType.AttrTypes, map[string]attr.Value{
+			"level":   types.StringValue(entry.Level),
+			"message": types.StringValue(entry.Message),
+		})
+		if diags.HasError() {
+			return types.ListNull(buildLogType), diags
+		}
+		logs[i] = objectValue
+	}
+
+	buildLogsList, diags := types.ListValue(buildLogType, logs)
+	if diags.HasError() {
+		return types.ListNull(buildLogType), diags
+	}
+
+	return buildLogsList, nil
+}
+
+// resolveURLToRoot resolves a file path given to the URL attributes, such as
+// entry_point_url, relative to the root directory. Empty strings and remote
+// URLs are returned as they are.
+func resolveURLToRoot(rootDir string, attrName string, u string) (string, diag.Diagnostic) {
+	if u == "" || strings.Contains(u, "://") {
+		return u, nil
+	}
+
+	rel, err := relativeToRoot(rootDir, u)
+	if err != nil {
+		return "", diag.NewAttributeErrorDiagnostic(
+			path.Root(attrName),
+			"Unable to Create Deployment",
+			fmt.Sprintf("Could not get file path relative to the root directory %s. target: %s", rootDir, u),
+		)
+	}
+	if rootDir != "." && isOutsideRoot(rel) {
+		return "", diag.NewAttributeErrorDiagnostic(
+			path.Root(attrName),
+			"Unable to Create Deployment",
+			fmt.Sprintf("%s is outside of the root directory %s", u, rootDir),
+		)
+	}
+
+	return rel, nil
+}
+
+// waitForDeployment polls the deployment until it reaches a final status, i.e.
+// "success" or "failed", or the given context is done.
+func (r *deploymentResource) waitForDeployment(ctx context.Context, deploymentID string, timeout time.Duration) (*client.Deployment, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	startedAt := time.Now()
+
+	ticker := time.NewTicker(DEPLOYMENT_POLLING_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		deployment, err := r.client.GetDeploymentWithResponse(ctx, deploymentID)
+		if err != nil {
+			if ctx.Err() != nil {
+				diags.AddError(
+					"Deployment Initiated, but Timed Out Waiting for Completion",
+					fmt.Sprintf("Deployment ID: %s\nTimed out after %s", deploymentID, timeout),
+				)
+				return nil, diags
+			}
+			diags.AddError(
+				"Deployment Initiated, but Failed to Get Deployment Details",
+				fmt.Sprintf("Deployment ID: %s\nError: %s", deploymentID, err.Error()),
+			)
+			return nil, diags
+		}
+		if err := client.CheckResponse(deployment); err != nil {
+			diags.AddError(
+				"Deployment Initiated, but Failed to Get Deployment Details",
+				fmt.Sprintf("Deployment ID: %s\nError: %s", deploymentID, apiErrorDetail(err)),
+			)
+			return nil, diags
+		}
+
+		switch deployment.JSON200.Status {
+		case client.DeploymentStatusSuccess, client.DeploymentStatusFailed:
+			tflog.Info(ctx, "Deployment completed", map[string]any{
+				"deployment_id": deploymentID,
+				"status":        deployment.JSON200.Status,
+				"elapsed":       time.Since(startedAt).String(),
+			})
+			return deployment.JSON200, diags
+		}
+
+		tflog.Info(ctx, "Waiting for deployment to complete", map[string]any{
+			"deployment_id": deploymentID,
+			"status":        deployment.JSON200.Status,
+			"elapsed":       time.Since(startedAt).String(),
+		})
+
+		select {
+		// timeout
+		case <-ctx.Done():
+			diags.AddError(
+				"Deployment Initiated, but Timed Out Waiting for Completion",
+				fmt.Sprintf("Deployment ID: %s\nStatus: %s\nTimed out after %s", deploymentID, deployment.JSON200.Status, timeout),
+			)
+			return nil, diags
+		// polling
+		case <-ticker.C:
+		}
+	}
+}
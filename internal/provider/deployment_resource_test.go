@@ -7,11 +7,15 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
+	"terraform-provider-deno/internal/fakedeploy"
 	"testing"
 	"time"
 	"unicode/utf8"
 
+	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -40,6 +44,17 @@ func TestAccDeployment_SingleFile(t *testing.T) {
 				`,
 				Check: resource.ComposeTestCheckFunc(testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("Hello world"))),
 			},
+			{
+				ResourceName:      "deno_deployment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["deno_deployment.test"].Primary.Attributes["deployment_id"], nil
+				},
+				ImportStateVerifyIdentifierAttribute: "deployment_id",
+				// These can't be retrieved from the API
+				ImportStateVerifyIgnore: []string{"root_dir", "entry_point_url", "import_map_url", "lock_file_url", "compiler_options", "assets", "uploaded_assets", "env_vars", "secret_env_vars", "build_logs", "timeouts"},
+			},
 		},
 	})
 }
@@ -99,6 +114,39 @@ func TestAccDeployment_MultiFile(t *testing.T) {
 	})
 }
 
+func TestAccDeployment_RootDir(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "deno_project" "test" {}
+
+					data "deno_assets" "test" {
+						root_dir = "testdata/multi-file"
+						glob = "**/*.{ts,json}"
+					}
+
+					resource "deno_deployment" "test" {
+						project_id = deno_project.test.id
+						root_dir = "testdata/multi-file"
+						entry_point_url = "testdata/multi-file/main.ts"
+						compiler_options = {}
+						assets = data.deno_assets.test.output
+						env_vars = {}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("sum: 42")),
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "3"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccDeployment_Symlink(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -282,6 +330,219 @@ func TestAccDeployment_EnvVars(t *testing.T) {
 	})
 }
 
+func TestAccDeployment_SecretEnvVars(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "deno_project" "test" {}
+
+					data "deno_assets" "test" {
+						glob = "testdata/env_var/main.ts"
+					}
+
+					resource "deno_deployment" "test" {
+						project_id = deno_project.test.id
+						entry_point_url = "testdata/env_var/main.ts"
+						compiler_options = {}
+						assets = data.deno_assets.test.output
+						env_vars = {}
+						secret_env_vars = {
+							"FOO" = "Secret"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("Hello Secret"))),
+			},
+		},
+	})
+}
+
+func TestAccDeployment_ConflictingEnvVars(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "deno_deployment" "test" {
+						project_id = "00000000-0000-0000-0000-000000000000"
+						entry_point_url = "testdata/env_var/main.ts"
+						assets = {}
+						env_vars = {
+							"FOO" = "Deno"
+						}
+						secret_env_vars = {
+							"FOO" = "Secret"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("Conflicting Environment Variable"),
+			},
+		},
+	})
+}
+
+func TestAccDeployment_Redeploy(t *testing.T) {
+	configWithGlob := func(glob string, greeting string) string {
+		return fmt.Sprintf(`
+			resource "deno_project" "test" {}
+
+			data "deno_assets" "test" {
+				glob = "%s"
+			}
+
+			resource "deno_deployment" "test" {
+				project_id = deno_project.test.id
+				entry_point_url = "testdata/env_var/main.ts"
+				compiler_options = {}
+				assets = data.deno_assets.test.output
+				env_vars = {
+					"FOO" = "%s"
+				}
+			}
+		`, glob, greeting)
+	}
+	config := func(greeting string) string {
+		return configWithGlob("testdata/env_var/main.ts", greeting)
+	}
+	var projectID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: config("Deno"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("Hello Deno")),
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "1"),
+					testAccCheckReceivedDeployments("deno_project.test", receivedDeployment{statusCode: http.StatusOK, hashOnlyAssets: []string{}}),
+				),
+			},
+			{
+				// The second deployment reuses the file uploaded in the first one.
+				Config: config("Terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("Hello Terraform")),
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "1"),
+					testAccCheckReceivedDeployments("deno_project.test", receivedDeployment{statusCode: http.StatusOK, hashOnlyAssets: []string{"testdata/env_var/main.ts"}}),
+					func(s *terraform.State) error {
+						projectID = s.RootModule().Resources["deno_project.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Errors that don't mention any hash-only asset are reported
+				// without retrying with the full content, which would succeed
+				// here.
+				PreConfig: func() {
+					if fakeServer != nil {
+						fakeServer.InjectFailure(fakedeploy.Failure{
+							Method:     http.MethodPost,
+							Path:       "/projects/",
+							StatusCode: http.StatusBadRequest,
+							Code:       "invalidEntryPoint",
+							Times:      1,
+						})
+					}
+				},
+				SkipFunc: func() (bool, error) {
+					return fakeServer == nil, nil
+				},
+				Config:      config("Invalid"),
+				ExpectError: regexp.MustCompile(`invalidEntryPoint`),
+			},
+			{
+				// The API no longer knows the hash, so the deployment falls back
+				// to uploading the full content.
+				PreConfig: func() {
+					if fakeServer != nil {
+						fakeServer.ForgetUploadedAssets(uuid.MustParse(projectID))
+					}
+				},
+				SkipFunc: func() (bool, error) {
+					return fakeServer == nil, nil
+				},
+				Config: config("Again"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("Hello Again")),
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "1"),
+					testAccCheckReceivedDeployments("deno_project.test",
+						receivedDeployment{statusCode: http.StatusBadRequest, hashOnlyAssets: []string{"testdata/env_var/main.ts"}},
+						receivedDeployment{statusCode: http.StatusOK, hashOnlyAssets: []string{}},
+					),
+				),
+			},
+			{
+				Config: configWithGlob("testdata/{env_var,single-file}/main.ts", "Both"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "2"),
+					testAccCheckReceivedDeployments("deno_project.test", receivedDeployment{statusCode: http.StatusOK, hashOnlyAssets: []string{"testdata/env_var/main.ts"}}),
+				),
+			},
+			{
+				// The removed file is still known to have been uploaded...
+				Config: config("Removed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "2"),
+				),
+			},
+			{
+				// ...so it is not uploaded again once restored.
+				Config: configWithGlob("testdata/{env_var,single-file}/main.ts", "Restored"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDeploymentDomains(t, "deno_deployment.test", []byte("Hello Restored")),
+					resource.TestCheckResourceAttr("deno_deployment.test", "uploaded_assets.%", "2"),
+					testAccCheckReceivedDeployments("deno_project.test", receivedDeployment{statusCode: http.StatusOK, hashOnlyAssets: []string{"testdata/env_var/main.ts", "testdata/single-file/main.ts"}}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDeployment_BuildTimeout(t *testing.T) {
+	if fakeServer == nil {
+		t.Skip("the build duration can only be controlled on the fake server")
+	}
+	fakeServer.SetBuildDuration(time.Hour)
+	t.Cleanup(func() { fakeServer.SetBuildDuration(0) })
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "deno_project" "test" {}
+
+					data "deno_assets" "test" {
+						glob = "testdata/single-file/main.ts"
+					}
+
+					resource "deno_deployment" "test" {
+						project_id = deno_project.test.id
+						entry_point_url = "testdata/single-file/main.ts"
+						assets = data.deno_assets.test.output
+						env_vars = {}
+						timeouts = {
+							create = "2s"
+						}
+					}
+				`,
+				// The build logs received before the timeout are reported
+				ExpectError: regexp.MustCompile(`Timed Out Waiting for Completion(.|\n)*Deploying\.\.\.`),
+			},
+		},
+	})
+}
+
 func TestAccDeployment_ConfigAutoDiscovery(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -328,9 +589,14 @@ func testAccCheckDeploymentDomains(t *testing.T, resourceName string, expectedRe
 		if err != nil {
 			return fmt.Errorf("failed to parse the number of domains: %s", err)
 		}
+		if numDomains == 0 {
+			return fmt.Errorf("deno_deployment resource has no domains to check the response of")
+		}
 
 		// Wait for a bit to make sure domain mapping update is propagated
-		time.Sleep(3 * time.Second)
+		if fakeServer == nil && cassetteMode() != cassetteModeReplay {
+			time.Sleep(3 * time.Second)
+		}
 
 		for i := 0; i < numDomains; i++ {
 			domain, ok := rs.Primary.Attributes[fmt.Sprintf("domains.%d", i)]
@@ -338,7 +604,7 @@ func testAccCheckDeploymentDomains(t *testing.T, resourceName string, expectedRe
 				return fmt.Errorf("deno_deployment resource is missing domains attribute")
 			}
 
-			resp, err := http.Get(fmt.Sprintf("https://%s", domain))
+			resp, err := getURL(fmt.Sprintf("https://%s", domain))
 			if err != nil {
 				return fmt.Errorf("failed to get the deployment (domain = %s): %s", domain, err)
 			}
@@ -371,6 +637,47 @@ func testAccCheckDeploymentDomains(t *testing.T, resourceName string, expectedRe
 	}
 }
 
+// receivedDeployment is the expectation of a request to create a deployment
+// received by the fake server.
+type receivedDeployment struct {
+	statusCode     int
+	hashOnlyAssets []string
+}
+
+// testAccCheckReceivedDeployments checks the latest requests to create a
+// deployment of the project that the fake server received, in order. It does
+// nothing when testing against the real API.
+func testAccCheckReceivedDeployments(projectResourceName string, expected ...receivedDeployment) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if fakeServer == nil {
+			return nil
+		}
+
+		rs, ok := s.RootModule().Resources[projectResourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", projectResourceName)
+		}
+		projectID, err := uuid.Parse(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to parse project ID %s: %s", rs.Primary.ID, err)
+		}
+
+		received := fakeServer.ReceivedDeployments(projectID)
+		if len(received) < len(expected) {
+			return fmt.Errorf("expected at least %d requests to create a deployment, but got %d", len(expected), len(received))
+		}
+		received = received[len(received)-len(expected):]
+		for i, e := range expected {
+			got := received[i].HashOnlyAssets()
+			if received[i].StatusCode != e.statusCode || !slices.Equal(got, e.hashOnlyAssets) {
+				return fmt.Errorf("request %d to create a deployment: expected status %d with hash-only assets %v, but got status %d with %v", i, e.statusCode, e.hashOnlyAssets, received[i].StatusCode, got)
+			}
+		}
+
+		return nil
+	}
+}
+
 // Deployments are immutable resources; destroy check will do nothing.
 func testAccDeploymentDestroy(t *testing.T) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
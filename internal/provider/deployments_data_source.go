@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &deploymentsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &deploymentsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &deploymentsDataSource{}
+)
+
+// deploymentObjectType is the type of each element of the deployments list.
+var deploymentObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"deployment_id": types.StringType,
+		"project_id":    types.StringType,
+		"status":        types.StringType,
+		"domains":       types.SetType{ElemType: types.StringType},
+		"created_at":    types.StringType,
+		"updated_at":    types.StringType,
+	},
+}
+
+// NewDeploymentsDataSource is a helper function to simplify the provider implementation.
+func NewDeploymentsDataSource() datasource.DataSource {
+	return &deploymentsDataSource{}
+}
+
+// deploymentsDataSource is the data source implementation.
+type deploymentsDataSource struct {
+	client client.ClientWithResponsesInterface
+}
+
+// deploymentsDataSourceModel maps the data source schema data.
+type deploymentsDataSourceModel struct {
+	ProjectID   types.String `tfsdk:"project_id"`
+	Status      types.String `tfsdk:"status"`
+	MostRecent  types.Bool   `tfsdk:"most_recent"`
+	Limit       types.Int64  `tfsdk:"limit"`
+	Deployments types.List   `tfsdk:"deployments"`
+}
+
+// Metadata returns the data source type name.
+func (d *deploymentsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deployments"
+}
+
+// Schema defines the schema for the data source.
+func (d *deploymentsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for the deployments of a project.
+
+The deployments are sorted from the most recent one. This is useful for looking up the latest successful deployment of a project, e.g. to associate a domain with it.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the project.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the deployments with this status. It can be either `pending`, `success` or `failed`.",
+			},
+			"most_recent": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the most recent deployment, after applying the status filter. This cannot be set along with `limit`.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of deployments to list, after applying the status filter. If not set, all the deployments are listed.",
+			},
+			"deployments": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The deployments, sorted from the most recent one.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"deployment_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the deployment.",
+						},
+						"project_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the project that the deployment belongs to.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "The status of the deployment. It can be either `pending`, `success` or `failed`.",
+						},
+						"domains": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The domains that the deployment is accessible with.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The time the deployment was created, formatted in RFC3339.",
+							MarkdownDescription: "The time the deployment was created, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The time the deployment was last updated, formatted in RFC3339.",
+							MarkdownDescription: "The time the deployment was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the filters.
+func (d *deploymentsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config deploymentsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Status.IsNull() && !config.Status.IsUnknown() {
+		switch client.DeploymentStatus(config.Status.ValueString()) {
+		case client.DeploymentStatusPending, client.DeploymentStatusSuccess, client.DeploymentStatusFailed:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("status"),
+				"Invalid Deployment Status",
+				fmt.Sprintf("status must be either pending, success or failed, got %s.", config.Status),
+			)
+		}
+	}
+
+	if !config.Limit.IsNull() && !config.Limit.IsUnknown() && config.Limit.ValueInt64() <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("limit"),
+			"Invalid Limit",
+			fmt.Sprintf("limit must be positive, got %d.", config.Limit.ValueInt64()),
+		)
+	}
+
+	if config.MostRecent.ValueBool() && !config.Limit.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("most_recent"),
+			"Conflicting Attributes",
+			"most_recent and limit cannot be set at the same time.",
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *deploymentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state deploymentsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := uuid.Parse(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project_id"),
+			fmt.Sprintf("Unable to List Deployments of Project %s", state.ProjectID),
+			fmt.Sprintf("Could not parse project ID %s: %s", state.ProjectID, err.Error()),
+		)
+		return
+	}
+
+	// The order of the list is not guaranteed, so every page is needed to
+	// find the most recent deployments
+	var deployments []client.Deployment
+	it := client.ListAllDeployments(ctx, d.client, projectID)
+	for it.Next() {
+		deployment := it.Value()
+		if !state.Status.IsNull() && string(deployment.Status) != state.Status.ValueString() {
+			continue
+		}
+		deployments = append(deployments, deployment)
+	}
+	if err := it.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to List Deployments of Project %s", state.ProjectID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	sort.Slice(deployments, func(i, j int) bool {
+		if !deployments[i].CreatedAt.Equal(deployments[j].CreatedAt) {
+			return deployments[i].CreatedAt.After(deployments[j].CreatedAt)
+		}
+		return deployments[i].Id < deployments[j].Id
+	})
+
+	limit := len(deployments)
+	if state.MostRecent.ValueBool() {
+		limit = 1
+	} else if !state.Limit.IsNull() {
+		limit = int(state.Limit.ValueInt64())
+	}
+	deployments = deployments[:min(limit, len(deployments))]
+
+	list, diags := convertToDeploymentsList(deployments)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Deployments = list
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func convertToDeploymentsList(deployments []client.Deployment) (types.List, diag.Diagnostics) {
+	elems := make([]attr.Value, len(deployments))
+	for i, deployment := range deployments {
+		domains := []attr.Value{}
+		if deployment.Domains != nil {
+			for _, domain := range *deployment.Domains {
+				domains = append(domains, types.StringValue(domain))
+			}
+		}
+		domainsSet, diags := types.SetValue(types.StringType, domains)
+		if diags.HasError() {
+			return types.ListNull(deploymentObjectType), diags
+		}
+
+		objectValue, diags := types.ObjectValue(deploymentObjectType.AttrTypes, map[string]attr.Value{
+			"deployment_id": types.StringValue(deployment.Id),
+			"project_id":    types.StringValue(deployment.ProjectId.String()),
+			"status":        types.StringValue(string(deployment.Status)),
+			"domains":       domainsSet,
+			"created_at":    types.StringValue(deployment.CreatedAt.Format(time.RFC3339)),
+			"updated_at":    types.StringValue(deployment.UpdatedAt.Format(time.RFC3339)),
+		})
+		if diags.HasError() {
+			return types.ListNull(deploymentObjectType), diags
+		}
+		elems[i] = objectValue
+	}
+
+	deploymentsList, diags := types.ListValue(deploymentObjectType, elems)
+	if diags.HasError() {
+		return types.ListNull(deploymentObjectType), diags
+	}
+
+	return deploymentsList, nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *deploymentsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+}
@@ -0,0 +1,86 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDeploymentsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				// The invalid configuration comes first, so that the resources
+				// are destroyed with a valid one at the end of the test
+				Config: `
+					data "deno_deployments" "test" {
+						project_id  = "00000000-0000-0000-0000-000000000000"
+						most_recent = true
+						limit       = 3
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Conflicting Attributes`),
+			},
+			{
+				Config: `
+					resource "deno_project" "test" {}
+
+					data "deno_assets" "test" {
+						glob = "testdata/single-file/main.ts"
+					}
+
+					resource "deno_deployment" "first" {
+						project_id = deno_project.test.id
+						entry_point_url = "testdata/single-file/main.ts"
+						assets = data.deno_assets.test.output
+						env_vars = {}
+					}
+
+					resource "deno_deployment" "second" {
+						project_id = deno_project.test.id
+						entry_point_url = "testdata/single-file/main.ts"
+						assets = data.deno_assets.test.output
+						env_vars = {}
+
+						depends_on = [deno_deployment.first]
+					}
+
+					data "deno_deployments" "all" {
+						project_id = deno_project.test.id
+
+						depends_on = [deno_deployment.second]
+					}
+
+					data "deno_deployments" "latest_success" {
+						project_id  = deno_project.test.id
+						status      = "success"
+						most_recent = true
+
+						depends_on = [deno_deployment.second]
+					}
+
+					data "deno_deployments" "limited" {
+						project_id = deno_project.test.id
+						limit      = 1
+
+						depends_on = [deno_deployment.second]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.deno_deployments.all", "deployments.#", "2"),
+					// Sorted from the most recent one
+					resource.TestCheckResourceAttrPair("data.deno_deployments.all", "deployments.0.deployment_id", "deno_deployment.second", "deployment_id"),
+					resource.TestCheckResourceAttrPair("data.deno_deployments.all", "deployments.1.deployment_id", "deno_deployment.first", "deployment_id"),
+					resource.TestCheckResourceAttr("data.deno_deployments.latest_success", "deployments.#", "1"),
+					resource.TestCheckResourceAttrPair("data.deno_deployments.latest_success", "deployments.0.deployment_id", "deno_deployment.second", "deployment_id"),
+					resource.TestCheckResourceAttr("data.deno_deployments.latest_success", "deployments.0.status", "success"),
+					resource.TestCheckResourceAttr("data.deno_deployments.limited", "deployments.#", "1"),
+				),
+			},
+		},
+	})
+}
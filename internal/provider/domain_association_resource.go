@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-deno/client"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &domainAssociationResource{}
+	_ resource.ResourceWithConfigure = &domainAssociationResource{}
+)
+
+// NewDomainAssociationResource is a helper function to simplify the provider implementation.
+func NewDomainAssociationResource() resource.Resource {
+	return &domainAssociationResource{}
+}
+
+// domainAssociationResource is the resource implementation.
+type domainAssociationResource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// domainAssociationResourceModel maps the resource schema data.
+type domainAssociationResourceModel struct {
+	DomainID     types.String `tfsdk:"domain_id"`
+	DeploymentID types.String `tfsdk:"deployment_id"`
+	ProjectID    types.String `tfsdk:"project_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *domainAssociationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_association"
+}
+
+// Schema defines the schema for the resource.
+func (r *domainAssociationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A resource for an association between a custom domain and a deployment.
+
+Once associated, requests to the custom domain are routed to the deployment. The domain's ownership must be verified and its certificates must be ready before the association. For more information regarding the setup process, please refer to the doc of deno_domain resource.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"domain_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The ID of the domain to associate with the deployment.",
+			},
+			"deployment_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the deployment that the domain points to. Changing this switches the association to the new deployment.",
+			},
+			"project_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the project that the deployment belongs to.",
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *domainAssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan domainAssociationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Associate the domain with the deployment
+	diags = r.associate(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *domainAssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state domainAssociationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainID, err := uuid.Parse(state.DomainID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Domain Association %s", state.DomainID),
+			fmt.Sprintf("Could not parse domain ID %s: %s", state.DomainID, err.Error()),
+		)
+		return
+	}
+
+	domain, err := r.client.GetDomainWithResponse(ctx, domainID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Domain Association %s", state.DomainID),
+			fmt.Sprintf("Could not find domain with ID %s: %s", state.DomainID, err.Error()),
+		)
+		return
+	}
+	if err := client.CheckResponse(domain); err != nil {
+		if client.IsNotFound(err) {
+			// The domain has been deleted outside of Terraform
+			tflog.Warn(ctx, "Domain not found, removing it from the state", map[string]any{
+				"domain_id": state.DomainID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Domain Association %s", state.DomainID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	// The API tells which project the domain is associated with, but not which
+	// deployment. If the domain has been detached or moved to another project
+	// outside of Terraform, remove the resource from the state so that the
+	// association is recreated.
+	projectID := domain.JSON200.ProjectId
+	if projectID == nil || projectID.String() != state.ProjectID.ValueString() {
+		tflog.Warn(ctx, "Domain association has been changed outside of Terraform", map[string]any{
+			"domain_id":           state.DomainID.ValueString(),
+			"expected_project_id": state.ProjectID.ValueString(),
+			"actual_project_id":   projectID,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *domainAssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Follow the same procedure as Create
+
+	// Retrieve values from plan
+	var plan domainAssociationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Switch the association to the new deployment
+	diags = r.associate(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *domainAssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state domainAssociationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainID, err := uuid.Parse(state.DomainID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Delete Domain Association %s", state.DomainID),
+			fmt.Sprintf("Could not parse domain ID %s: %s", state.DomainID, err.Error()),
+		)
+		return
+	}
+
+	// Detach the domain by associating it with no deployment
+	result, err := r.client.UpdateDomainAssociationWithResponse(ctx, domainID, client.UpdateDomainAssociationRequest{
+		DeploymentId: nil,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Delete Domain Association %s", state.DomainID),
+			err.Error(),
+		)
+		return
+	}
+	if err := client.CheckResponse(result); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Delete Domain Association %s", state.DomainID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *domainAssociationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+	r.organizationID = providerData.organizationID
+}
+
+// associate associates the domain with the deployment in the plan, and fills
+// in the project ID the domain is now associated with.
+func (r *domainAssociationResource) associate(ctx context.Context, plan *domainAssociationResourceModel) diag.Diagnostics {
+	accumulatedDiags := diag.Diagnostics{}
+
+	domainID, err := uuid.Parse(plan.DomainID.ValueString())
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Associate Domain %s", plan.DomainID),
+			fmt.Sprintf("Could not parse domain ID %s: %s", plan.DomainID, err.Error()),
+		)
+		return accumulatedDiags
+	}
+
+	deploymentID := plan.DeploymentID.ValueString()
+	result, err := r.client.UpdateDomainAssociationWithResponse(ctx, domainID, client.UpdateDomainAssociationRequest{
+		DeploymentId: &deploymentID,
+	})
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Associate Domain %s with Deployment %s", plan.DomainID, deploymentID),
+			err.Error(),
+		)
+		return accumulatedDiags
+	}
+	if err := client.CheckResponse(result); err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Associate Domain %s with Deployment %s", plan.DomainID, deploymentID),
+			apiErrorDetail(err),
+		)
+		return accumulatedDiags
+	}
+
+	// Get the project the domain is now associated with, which is used to
+	// detect drift later on
+	domain, err := r.client.GetDomainWithResponse(ctx, domainID)
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Domain %s Associated, but Failed to Get Domain Details", plan.DomainID),
+			err.Error(),
+		)
+		return accumulatedDiags
+	}
+	if err := client.CheckResponse(domain); err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Domain %s Associated, but Failed to Get Domain Details", plan.DomainID),
+			apiErrorDetail(err),
+		)
+		return accumulatedDiags
+	}
+	if domain.JSON200.ProjectId == nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Domain %s Associated, but Not Reflected", plan.DomainID),
+			"The domain is not associated with any project after the association was requested. Please try again later.",
+		)
+		return accumulatedDiags
+	}
+
+	plan.ProjectID = types.StringValue(domain.JSON200.ProjectId.String())
+
+	return accumulatedDiags
+}
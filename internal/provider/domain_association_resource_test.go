@@ -0,0 +1,206 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"terraform-provider-deno/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/thanhpk/randstr"
+)
+
+func TestAccDomainAssociation(t *testing.T) {
+	if fakeServer == nil {
+		t.Skip("domain ownership can only be verified on the fake server")
+	}
+
+	domainName := fmt.Sprintf("%s.example.com", randstr.String(16, letters))
+	baseConfig := fmt.Sprintf(`
+		resource "deno_project" "test" {}
+
+		resource "deno_project" "other" {}
+
+		data "deno_assets" "test" {
+			glob = "testdata/single-file/main.ts"
+		}
+
+		resource "deno_deployment" "first" {
+			project_id = deno_project.test.id
+			entry_point_url = "testdata/single-file/main.ts"
+			assets = data.deno_assets.test.output
+			env_vars = {}
+		}
+
+		resource "deno_deployment" "second" {
+			project_id = deno_project.test.id
+			entry_point_url = "testdata/single-file/main.ts"
+			assets = data.deno_assets.test.output
+			env_vars = {
+				"FOO" = "second"
+			}
+		}
+
+		resource "deno_deployment" "other" {
+			project_id = deno_project.other.id
+			entry_point_url = "testdata/single-file/main.ts"
+			assets = data.deno_assets.test.output
+			env_vars = {}
+		}
+
+		resource "deno_domain" "test" {
+			domain = "%s"
+		}
+
+		resource "deno_domain_verification" "test" {
+			domain_id = deno_domain.test.id
+		}
+	`, domainName)
+	config := func(deployment string) string {
+		return baseConfig + fmt.Sprintf(`
+			resource "deno_domain_association" "test" {
+				depends_on = [deno_domain_verification.test]
+
+				domain_id     = deno_domain.test.id
+				deployment_id = deno_deployment.%s.deployment_id
+			}
+		`, deployment)
+	}
+
+	var domainID uuid.UUID
+	var otherDeploymentID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDeploymentDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: config("first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("deno_domain_association.test", "domain_id", "deno_domain.test", "id"),
+					resource.TestCheckResourceAttrPair("deno_domain_association.test", "deployment_id", "deno_deployment.first", "deployment_id"),
+					resource.TestCheckResourceAttrPair("deno_domain_association.test", "project_id", "deno_project.test", "id"),
+					testAccCheckDomainAssociatedWith("deno_domain.test", "deno_deployment.first"),
+					func(s *terraform.State) error {
+						var err error
+						domainID, err = uuid.Parse(s.RootModule().Resources["deno_domain.test"].Primary.ID)
+						otherDeploymentID = s.RootModule().Resources["deno_deployment.other"].Primary.Attributes["deployment_id"]
+						return err
+					},
+				),
+			},
+			{
+				// Switching the deployment updates the association in place,
+				// without detaching the domain in between
+				Config: config("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("deno_domain_association.test", "deployment_id", "deno_deployment.second", "deployment_id"),
+					testAccCheckDomainAssociatedWith("deno_domain.test", "deno_deployment.second"),
+					func(s *terraform.State) error {
+						requests := fakeServer.DomainAssociationRequests(domainID)
+						if len(requests) != 2 {
+							return fmt.Errorf("expected 2 requests to update the association, but got %d: %s", len(requests), requests)
+						}
+						for _, body := range requests {
+							if deploymentID := requestedDeploymentID(body); deploymentID == "null" {
+								return fmt.Errorf("expected no request to detach the domain, but got %s", body)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Moving the domain to another project outside of Terraform is
+				// detected as drift
+				PreConfig: func() {
+					result, err := getAPIClient(t).UpdateDomainAssociationWithResponse(context.Background(), domainID, client.UpdateDomainAssociationRequest{
+						DeploymentId: &otherDeploymentID,
+					})
+					if err == nil {
+						err = client.CheckResponse(result)
+					}
+					if err != nil {
+						t.Fatalf("failed to move the domain to another project: %s", err)
+					}
+				},
+				Config:             config("second"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Applying the drift associates the domain with the deployment
+				// again
+				Config: config("second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("deno_domain_association.test", "project_id", "deno_project.test", "id"),
+					testAccCheckDomainAssociatedWith("deno_domain.test", "deno_deployment.second"),
+				),
+			},
+			{
+				// Destroying the association detaches the domain
+				Config: baseConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDomainAssociatedWith("deno_domain.test", ""),
+					func(s *terraform.State) error {
+						requests := fakeServer.DomainAssociationRequests(domainID)
+						last := requests[len(requests)-1]
+						if deploymentID := requestedDeploymentID(last); deploymentID != "null" {
+							return fmt.Errorf("expected the domain to be detached with a null deploymentId, but got %s", last)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckDomainAssociatedWith checks on the fake server that the domain
+// is associated with the deployment, or with no deployment if
+// deploymentResourceName is empty.
+func testAccCheckDomainAssociatedWith(domainResourceName string, deploymentResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[domainResourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", domainResourceName)
+		}
+		domainID, err := uuid.Parse(rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("failed to parse domain ID %s: %s", rs.Primary.ID, err)
+		}
+
+		expected := ""
+		if deploymentResourceName != "" {
+			rs, ok := s.RootModule().Resources[deploymentResourceName]
+			if !ok {
+				return fmt.Errorf("not found: %s", deploymentResourceName)
+			}
+			expected = rs.Primary.Attributes["deployment_id"]
+		}
+
+		got := ""
+		if deploymentID := fakeServer.DomainDeploymentID(domainID); deploymentID != nil {
+			got = *deploymentID
+		}
+		if got != expected {
+			return fmt.Errorf("expected the domain to be associated with deployment %q, but got %q", expected, got)
+		}
+		return nil
+	}
+}
+
+// requestedDeploymentID returns the raw JSON value of deploymentId in the body
+// of a request to update a domain association, or an empty string if it is
+// missing.
+func requestedDeploymentID(body json.RawMessage) string {
+	var req map[string]json.RawMessage
+	if err := json.Unmarshal(body, &req); err != nil {
+		return ""
+	}
+	return string(req["deploymentId"])
+}
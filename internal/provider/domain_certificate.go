@@ -2,11 +2,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-deno/client"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -15,10 +19,25 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &certificateProvisioningResource{}
-	_ resource.ResourceWithConfigure = &certificateProvisioningResource{}
+	_ resource.Resource                = &certificateProvisioningResource{}
+	_ resource.ResourceWithConfigure   = &certificateProvisioningResource{}
+	_ resource.ResourceWithImportState = &certificateProvisioningResource{}
 )
 
+const (
+	// DEFAULT_CERTIFICATE_PROVISIONING_TIMEOUT is the default time to wait for
+	// certificate provisioning to complete.
+	DEFAULT_CERTIFICATE_PROVISIONING_TIMEOUT = 10 * time.Minute
+	// CERTIFICATE_PROVISIONING_POLLING_INTERVAL is the interval between status
+	// checks while waiting for certificate provisioning to complete.
+	CERTIFICATE_PROVISIONING_POLLING_INTERVAL = 10 * time.Second
+)
+
+// certificateProvisioningPollingInterval is the interval actually used while
+// waiting for certificate provisioning. Tests shorten it so that they don't
+// have to wait CERTIFICATE_PROVISIONING_POLLING_INTERVAL per status check.
+var certificateProvisioningPollingInterval = CERTIFICATE_PROVISIONING_POLLING_INTERVAL
+
 // NewCertificateProvisioningResource is a helper function to simplify the provider implementation.
 func NewCertificateProvisioningResource() resource.Resource {
 	return &certificateProvisioningResource{}
@@ -32,8 +51,9 @@ type certificateProvisioningResource struct {
 
 // certificateProvisioningResourceModel maps the resource schema data.
 type certificateProvisioningResourceModel struct {
-	DomainID           types.String `tfsdk:"domain_id"`
-	ProvisioningStatus types.String `tfsdk:"provisioning_status"`
+	DomainID           types.String   `tfsdk:"domain_id"`
+	ProvisioningStatus types.String   `tfsdk:"provisioning_status"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -58,6 +78,10 @@ In order to assoaicte a custom domain with a deployment, certificates needs to b
 				Computed:    true,
 				Description: "The status of the certificate provisioning. Possible values are `success`, `failed`, `pending`, and `manual`.",
 			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
 		},
 	}
 }
@@ -72,6 +96,12 @@ func (r *certificateProvisioningResource) Create(ctx context.Context, req resour
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Create(ctx, DEFAULT_CERTIFICATE_PROVISIONING_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	domainID, err := uuid.Parse(plan.DomainID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -91,23 +121,24 @@ func (r *certificateProvisioningResource) Create(ctx context.Context, req resour
 		)
 		return
 	}
-	if client.RespIsError(result) {
+	if err := client.CheckResponse(result); err != nil {
 		tflog.Debug(ctx, "Provision API returned error", map[string]any{
-			"400": result.JSON400,
-			"401": result.JSON401,
-			"404": result.JSON404,
+			"trace_id": result.TraceID(),
+			"400":      result.JSON400,
+			"401":      result.JSON401,
+			"404":      result.JSON404,
 		})
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Provision Certificates for Domain %s", plan.DomainID),
-			client.APIErrorDetail(result.HTTPResponse, result.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
 
-	// Call the API to get the provisioning status
-	provisioningStatus, diag := r.getCurrentProvisioningStatus(ctx, domainID)
-	resp.Diagnostics.Append(diag)
-	if resp.Diagnostics.HasError() {
+	// Wait for the provisioning to complete
+	provisioningStatus, waitDiags := r.waitForProvisioning(ctx, domainID, timeout)
+	if provisioningStatus == "" {
+		resp.Diagnostics.Append(waitDiags...)
 		return
 	}
 
@@ -121,13 +152,7 @@ func (r *certificateProvisioningResource) Create(ctx context.Context, req resour
 		return
 	}
 
-	if provisioningStatus != "success" {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
-			fmt.Sprintf("Provisioning status is %s, expected success", provisioningStatus),
-		)
-		return
-	}
+	resp.Diagnostics.Append(waitDiags...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -149,11 +174,19 @@ func (r *certificateProvisioningResource) Read(ctx context.Context, req resource
 		return
 	}
 
-	provisioningStatus, diag := r.getCurrentProvisioningStatus(ctx, domainID)
+	provisioningStatus, _, diag := r.getCurrentProvisioningStatus(ctx, domainID)
 	resp.Diagnostics.Append(diag)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if provisioningStatus == "" {
+		// The domain has been deleted outside of Terraform
+		tflog.Warn(ctx, "Domain not found, removing it from the state", map[string]any{
+			"domain_id": state.DomainID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
 	state.ProvisioningStatus = types.StringValue(provisioningStatus)
 
@@ -177,6 +210,12 @@ func (r *certificateProvisioningResource) Update(ctx context.Context, req resour
 		return
 	}
 
+	timeout, diags := plan.Timeouts.Update(ctx, DEFAULT_CERTIFICATE_PROVISIONING_TIMEOUT)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	domainID, err := uuid.Parse(plan.DomainID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -196,18 +235,18 @@ func (r *certificateProvisioningResource) Update(ctx context.Context, req resour
 		)
 		return
 	}
-	if client.RespIsError(result) {
+	if err := client.CheckResponse(result); err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Provision Certificates for Domain %s", plan.DomainID),
-			client.APIErrorDetail(result.HTTPResponse, result.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
 
-	// Call the API to get the provisioning status
-	provisioningStatus, diag := r.getCurrentProvisioningStatus(ctx, domainID)
-	resp.Diagnostics.Append(diag)
-	if resp.Diagnostics.HasError() {
+	// Wait for the provisioning to complete
+	provisioningStatus, waitDiags := r.waitForProvisioning(ctx, domainID, timeout)
+	if provisioningStatus == "" {
+		resp.Diagnostics.Append(waitDiags...)
 		return
 	}
 
@@ -221,13 +260,7 @@ func (r *certificateProvisioningResource) Update(ctx context.Context, req resour
 		return
 	}
 
-	if provisioningStatus != "success" {
-		resp.Diagnostics.AddError(
-			fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
-			fmt.Sprintf("Provisioning status is %s, expected success", provisioningStatus),
-		)
-		return
-	}
+	resp.Diagnostics.Append(waitDiags...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -235,6 +268,13 @@ func (r *certificateProvisioningResource) Delete(ctx context.Context, req resour
 	// noop
 }
 
+// ImportState imports the existing resource into Terraform. The import ID is
+// the ID of the domain.
+func (r *certificateProvisioningResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to domain_id attribute
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_id"), req, resp)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *certificateProvisioningResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -256,44 +296,144 @@ func (r *certificateProvisioningResource) Configure(_ context.Context, req resou
 	r.organizationID = providerData.organizationID
 }
 
-func (r *certificateProvisioningResource) getCurrentProvisioningStatus(ctx context.Context, domainID uuid.UUID) (string, diag.Diagnostic) {
+// waitForProvisioning polls the provisioning status of the domain until it
+// becomes other than "pending", or the timeout is reached. It returns the
+// last seen status, along with an error diagnostic explaining why the
+// provisioning has not succeeded if that's the case. The returned status is
+// empty if it couldn't be retrieved at all.
+func (r *certificateProvisioningResource) waitForProvisioning(ctx context.Context, domainID uuid.UUID, timeout time.Duration) (string, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(certificateProvisioningPollingInterval)
+	defer ticker.Stop()
+
+	timedOut := func(status string) (string, diag.Diagnostics) {
+		diags.AddError(
+			fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
+			fmt.Sprintf("Provisioning status is still %s after %s", status, timeout),
+		)
+		return status, diags
+	}
+
+	startedAt := time.Now()
+	lastStatus := ""
+	for {
+		status, message, d := r.getCurrentProvisioningStatus(ctx, domainID)
+		if d != nil {
+			// The timeout can be reached in the middle of a status check
+			if lastStatus != "" && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return timedOut(lastStatus)
+			}
+			diags.Append(d)
+			return "", diags
+		}
+		lastStatus = status
+
+		switch status {
+		case "":
+			diags.AddError(
+				fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
+				fmt.Sprintf("Domain %s was not found.", domainID),
+			)
+			return "", diags
+		case "success":
+			return status, diags
+		case "failed":
+			diags.AddError(
+				fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
+				fmt.Sprintf("Provisioning failed: %s", message),
+			)
+			return status, diags
+		case "manual":
+			diags.AddError(
+				fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
+				"Certificates for this domain are managed manually, so they are not provisioned automatically. "+
+					"Either upload your own certificate with the deno_domain_custom_certificate resource instead of this resource, "+
+					"or remove the uploaded certificates from the domain in the dashboard to let Deno Deploy provision them.",
+			)
+			return status, diags
+		case "pending":
+			tflog.Info(ctx, "Waiting for certificate provisioning to complete", map[string]any{
+				"domain_id": domainID.String(),
+				"elapsed":   time.Since(startedAt).String(),
+			})
+		default:
+			diags.AddError(
+				fmt.Sprintf("Unable to Provision Certificates for Domain %s", domainID),
+				fmt.Sprintf("Provisioning status is %s, expected success", status),
+			)
+			return status, diags
+		}
+
+		select {
+		// timeout
+		case <-ctx.Done():
+			return timedOut(status)
+		// polling
+		case <-ticker.C:
+		}
+	}
+}
+
+// getCurrentProvisioningStatus returns the provisioning status of the domain,
+// along with the failure message if the status is "failed". The status is
+// empty if the domain doesn't exist.
+func (r *certificateProvisioningResource) getCurrentProvisioningStatus(ctx context.Context, domainID uuid.UUID) (string, string, diag.Diagnostic) {
 	domain, err := r.client.GetDomainWithResponse(ctx, domainID)
 	if err != nil {
 		d := diag.NewErrorDiagnostic(
 			fmt.Sprintf("Failed to Get Domain Info for Domain %s", domainID),
 			fmt.Sprintf("GetDomain API returned error: %s", err.Error()),
 		)
-		return "", d
+		return "", "", d
 	}
-	if client.RespIsError(domain) {
+	if err := client.CheckResponse(domain); err != nil {
+		if client.IsNotFound(err) {
+			return "", "", nil
+		}
 		d := diag.NewErrorDiagnostic(
 			fmt.Sprintf("Failed to Get Domain Info for Domain %s", domainID),
-			client.APIErrorDetail(domain.HTTPResponse, domain.Body),
+			apiErrorDetail(err),
 		)
-		return "", d
+		return "", "", d
 	}
 
-	status, err := domain.JSON200.ProvisioningStatus.ValueByDiscriminator()
+	status, message, err := provisioningStatusString(domain.JSON200.ProvisioningStatus)
 	if err != nil {
 		d := diag.NewErrorDiagnostic(
 			"Failed to Get Provisioning Status",
 			err.Error(),
 		)
-		return "", d
+		return "", "", d
+	}
+
+	return status, message, nil
+}
+
+// provisioningStatusString converts the provisioning status to its string
+// representation, along with the error message if provisioning failed.
+func provisioningStatusString(provisioningStatus client.ProvisioningStatus) (string, string, error) {
+	status, err := provisioningStatus.ValueByDiscriminator()
+	if err != nil {
+		return "", "", err
 	}
 
-	// Convert provisioning status to string representation
 	ret := "unknown"
-	switch status.(type) {
+	message := ""
+	switch s := status.(type) {
 	case client.ProvisioningStatusSuccess:
 		ret = "success"
 	case client.ProvisioningStatusFailed:
 		ret = "failed"
+		message = s.Message
 	case client.ProvisioningStatusPending:
 		ret = "pending"
 	case client.ProvisioningStatusManual:
 		ret = "manual"
 	}
 
-	return ret, nil
+	return ret, message, nil
 }
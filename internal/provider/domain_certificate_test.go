@@ -0,0 +1,137 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-deno/client"
+	"terraform-provider-deno/internal/provider"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/thanhpk/randstr"
+)
+
+func TestAccDomainCertificate(t *testing.T) {
+	if fakeServer == nil {
+		t.Skip("domain ownership can only be verified on the fake server")
+	}
+	provider.SetCertificateProvisioningPollingInterval(t, 10*time.Millisecond)
+
+	names := []string{"pending", "failed", "manual", "timeout"}
+	domainNames := map[string]string{}
+	for _, name := range names {
+		domainNames[name] = fmt.Sprintf("%s.example.com", randstr.String(16, letters))
+	}
+	chain, key := generateTestCertificate(t, []string{domainNames["manual"]}, time.Now().Add(24*time.Hour))
+
+	baseConfig := fmt.Sprintf(`
+		resource "deno_domain" "pending" {
+			domain = "%s"
+		}
+
+		resource "deno_domain" "failed" {
+			domain = "%s"
+		}
+
+		resource "deno_domain" "manual" {
+			domain = "%s"
+		}
+
+		resource "deno_domain" "timeout" {
+			domain = "%s"
+		}
+
+		resource "deno_domain_custom_certificate" "manual" {
+			domain_id         = deno_domain.manual.id
+			certificate_chain = <<-EOT
+%sEOT
+			private_key       = <<-EOT
+%sEOT
+		}
+	`, domainNames["pending"], domainNames["failed"], domainNames["manual"], domainNames["timeout"], chain, key)
+	config := func(name string, timeout string) string {
+		timeouts := ""
+		if timeout != "" {
+			timeouts = fmt.Sprintf(`timeouts = { create = "%s" }`, timeout)
+		}
+		return baseConfig + fmt.Sprintf(`
+			resource "deno_domain_certificate" "%[1]s" {
+				domain_id = deno_domain.%[1]s.id
+				%[2]s
+			}
+		`, name, timeouts)
+	}
+
+	domainIDs := map[string]uuid.UUID{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDomainDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: baseConfig,
+				Check: func(s *terraform.State) error {
+					for _, name := range names {
+						id, err := uuid.Parse(s.RootModule().Resources["deno_domain."+name].Primary.ID)
+						if err != nil {
+							return err
+						}
+						domainIDs[name] = id
+					}
+					return nil
+				},
+			},
+			{
+				// Provisioning is waited for while it is pending
+				PreConfig: func() {
+					for _, name := range names {
+						result, err := getAPIClient(t).VerifyDomainWithResponse(context.Background(), domainIDs[name])
+						if err == nil {
+							err = client.CheckResponse(result)
+						}
+						if err != nil {
+							t.Fatalf("failed to verify domain %s: %s", domainIDs[name], err)
+						}
+					}
+					fakeServer.DelayCertificateProvisioning(domainIDs["pending"], 3)
+					fakeServer.FailCertificateProvisioning(domainIDs["failed"], "CAA record forbids issuance")
+					fakeServer.DelayCertificateProvisioning(domainIDs["timeout"], 1000)
+				},
+				Config: config("pending", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("deno_domain_certificate.pending", "domain_id", "deno_domain.pending", "id"),
+					resource.TestCheckResourceAttr("deno_domain_certificate.pending", "provisioning_status", "success"),
+				),
+			},
+			{
+				// Import by domain ID
+				ResourceName: "deno_domain_certificate.pending",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return domainIDs["pending"].String(), nil
+				},
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "domain_id",
+				ImportStateVerifyIgnore:              []string{"timeouts"},
+			},
+			{
+				Config:      config("failed", ""),
+				ExpectError: regexp.MustCompile(`Provisioning failed: CAA record forbids issuance`),
+			},
+			{
+				// The domain has the custom certificate uploaded
+				Config:      config("manual", ""),
+				ExpectError: regexp.MustCompile(`Certificates for this domain are managed manually`),
+			},
+			{
+				Config:      config("timeout", "1s"),
+				ExpectError: regexp.MustCompile(`Provisioning status is still pending after 1s`),
+			},
+		},
+	})
+}
@@ -0,0 +1,482 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &customCertificateResource{}
+	_ resource.ResourceWithConfigure      = &customCertificateResource{}
+	_ resource.ResourceWithValidateConfig = &customCertificateResource{}
+)
+
+// NewCustomCertificateResource is a helper function to simplify the provider implementation.
+func NewCustomCertificateResource() resource.Resource {
+	return &customCertificateResource{}
+}
+
+// customCertificateResource is the resource implementation.
+type customCertificateResource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// customCertificateResourceModel maps the resource schema data.
+type customCertificateResourceModel struct {
+	DomainID         types.String `tfsdk:"domain_id"`
+	CertificateChain types.String `tfsdk:"certificate_chain"`
+	PrivateKey       types.String `tfsdk:"private_key"`
+	Cipher           types.String `tfsdk:"cipher"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+}
+
+// Metadata returns the resource type name.
+func (r *customCertificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_custom_certificate"
+}
+
+// Schema defines the schema for the resource.
+func (r *customCertificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A resource for a custom TLS certificate of a custom domain.
+
+This is an alternative to deno_domain_certificate resource for when the certificate is issued by your own certificate authority. Before uploading, the certificate chain is checked to be ordered from the leaf certificate, to cover the domain, and to match the private key.
+Note that destroying this resource doesn't remove the uploaded certificate from the domain.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"domain_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "The ID of the domain to upload the certificate for.",
+			},
+			"certificate_chain": schema.StringAttribute{
+				Required:    true,
+				Description: "The PEM encoded certificate chain, starting with the leaf certificate for the domain, followed by the intermediate certificates in order.",
+			},
+			"private_key": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The PEM encoded private key for the leaf certificate.",
+			},
+			"cipher": schema.StringAttribute{
+				Computed:    true,
+				Description: "The cipher of the certificate. Possible values are `rsa` and `ec`.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the certificate expires, formatted in RFC3339.",
+				MarkdownDescription: "The time the certificate expires, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the resource configuration.
+func (r *customCertificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config customCertificateResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The certificate can't be checked until the values are known
+	if config.CertificateChain.IsUnknown() || config.PrivateKey.IsUnknown() {
+		return
+	}
+
+	_, err := parseCertificateChain(config.CertificateChain.ValueString(), config.PrivateKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate_chain"),
+			"Invalid Certificate",
+			err.Error(),
+		)
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *customCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Retrieve values from plan
+	var plan customCertificateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Upload the certificate
+	diags = r.upload(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *customCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state customCertificateResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainID, err := uuid.Parse(state.DomainID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Custom Certificate for Domain %s", state.DomainID),
+			fmt.Sprintf("Could not parse domain ID %s: %s", state.DomainID, err.Error()),
+		)
+		return
+	}
+
+	domain, err := r.client.GetDomainWithResponse(ctx, domainID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Custom Certificate for Domain %s", state.DomainID),
+			fmt.Sprintf("Could not find domain with ID %s: %s", state.DomainID, err.Error()),
+		)
+		return
+	}
+	if err := client.CheckResponse(domain); err != nil {
+		if client.IsNotFound(err) {
+			// The domain has been deleted outside of Terraform
+			tflog.Warn(ctx, "Domain not found, removing it from the state", map[string]any{
+				"domain_id": state.DomainID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Custom Certificate for Domain %s", state.DomainID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	// If the certificate is no longer there, remove the resource from the
+	// state so that it is uploaded again
+	cert := findDomainCertificate(domain.JSON200.Certificates, client.TlsCipher(state.Cipher.ValueString()), nil)
+	if cert == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ExpiresAt = types.StringValue(cert.ExpiresAt.Format(time.RFC3339))
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Follow the same procedure as Create
+
+	// Retrieve values from plan
+	var plan customCertificateResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Upload the certificate
+	diags = r.upload(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *customCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// noop
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customCertificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+	r.organizationID = providerData.organizationID
+}
+
+// upload checks the certificate in the plan against the domain, uploads it,
+// and fills in the computed attributes.
+func (r *customCertificateResource) upload(ctx context.Context, plan *customCertificateResourceModel) diag.Diagnostics {
+	accumulatedDiags := diag.Diagnostics{}
+
+	domainID, err := uuid.Parse(plan.DomainID.ValueString())
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			fmt.Sprintf("Could not parse domain ID %s: %s", plan.DomainID, err.Error()),
+		)
+		return accumulatedDiags
+	}
+
+	leaf, err := parseCertificateChain(plan.CertificateChain.ValueString(), plan.PrivateKey.ValueString())
+	if err != nil {
+		accumulatedDiags.AddAttributeError(
+			path.Root("certificate_chain"),
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			err.Error(),
+		)
+		return accumulatedDiags
+	}
+
+	// Expiry is not an error, so that a configuration holding an expired
+	// certificate can still be planned and applied until it is renewed
+	if time.Now().After(leaf.NotAfter) {
+		accumulatedDiags.AddAttributeWarning(
+			path.Root("certificate_chain"),
+			"Expired Certificate",
+			fmt.Sprintf("The leaf certificate expired at %s, so the domain %s won't be served with it. Please renew the certificate.", leaf.NotAfter.Format(time.RFC3339), plan.DomainID),
+		)
+	}
+
+	cipher, err := certificateCipher(leaf)
+	if err != nil {
+		accumulatedDiags.AddAttributeError(
+			path.Root("certificate_chain"),
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			err.Error(),
+		)
+		return accumulatedDiags
+	}
+
+	// Make sure the certificate covers the domain before uploading
+	domain, err := r.client.GetDomainWithResponse(ctx, domainID)
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			fmt.Sprintf("Could not find domain with ID %s: %s", plan.DomainID, err.Error()),
+		)
+		return accumulatedDiags
+	}
+	if err := client.CheckResponse(domain); err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			apiErrorDetail(err),
+		)
+		return accumulatedDiags
+	}
+	if err := leaf.VerifyHostname(domain.JSON200.Domain); err != nil {
+		accumulatedDiags.AddAttributeError(
+			path.Root("certificate_chain"),
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			fmt.Sprintf("The leaf certificate does not cover the domain %s: %s", domain.JSON200.Domain, err.Error()),
+		)
+		return accumulatedDiags
+	}
+
+	result, err := r.client.AddDomainCertificateWithResponse(ctx, domainID, client.AddDomainCertificateRequest{
+		CertificateChain: plan.CertificateChain.ValueString(),
+		PrivateKey:       plan.PrivateKey.ValueString(),
+	})
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			err.Error(),
+		)
+		return accumulatedDiags
+	}
+	if err := client.CheckResponse(result); err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Unable to Upload Certificate for Domain %s", plan.DomainID),
+			apiErrorDetail(err),
+		)
+		return accumulatedDiags
+	}
+
+	// Get the uploaded certificate details
+	domain, err = r.client.GetDomainWithResponse(ctx, domainID)
+	if err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Certificate Uploaded, but Failed to Get Domain Details %s", plan.DomainID),
+			err.Error(),
+		)
+		return accumulatedDiags
+	}
+	if err := client.CheckResponse(domain); err != nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Certificate Uploaded, but Failed to Get Domain Details %s", plan.DomainID),
+			apiErrorDetail(err),
+		)
+		return accumulatedDiags
+	}
+
+	cert := findDomainCertificate(domain.JSON200.Certificates, cipher, &leaf.NotAfter)
+	if cert == nil {
+		accumulatedDiags.AddError(
+			fmt.Sprintf("Certificate Uploaded, but Not Found %s", plan.DomainID),
+			fmt.Sprintf("No %s certificate is found in the domain after the upload. Please try again later.", cipher),
+		)
+		return accumulatedDiags
+	}
+
+	plan.Cipher = types.StringValue(string(cert.Cipher))
+	plan.ExpiresAt = types.StringValue(cert.ExpiresAt.Format(time.RFC3339))
+
+	return accumulatedDiags
+}
+
+// findDomainCertificate finds the certificate with the given cipher. If
+// expiresAt is given, a certificate expiring at that time is preferred.
+// Otherwise the most recently updated one is returned.
+func findDomainCertificate(certs []client.DomainCertificate, cipher client.TlsCipher, expiresAt *time.Time) *client.DomainCertificate {
+	var found *client.DomainCertificate
+	for i := range certs {
+		cert := &certs[i]
+		if cert.Cipher != cipher {
+			continue
+		}
+		if expiresAt != nil && cert.ExpiresAt.Truncate(time.Second).Equal(expiresAt.Truncate(time.Second)) {
+			return cert
+		}
+		if found == nil || cert.UpdatedAt.After(found.UpdatedAt) {
+			found = cert
+		}
+	}
+	return found
+}
+
+// parseCertificateChain parses the PEM encoded certificate chain and private
+// key, and checks that the chain is ordered from the leaf certificate and
+// that the private key matches the leaf certificate. It returns the leaf
+// certificate.
+func parseCertificateChain(chainPEM string, keyPEM string) (*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(chainPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %s is found in the certificate chain", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d in the chain: %w", len(certs), err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate is found in the certificate chain")
+	}
+
+	// Each certificate must be signed by the following one
+	for i := 0; i < len(certs)-1; i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			return nil, fmt.Errorf("certificate %d (%s) is not signed by certificate %d (%s); the chain must be ordered from the leaf certificate to the root: %w", i, certs[i].Subject, i+1, certs[i+1].Subject, err)
+		}
+	}
+
+	leaf := certs[0]
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := leaf.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(key.Public()) {
+		return nil, errors.New("the private key does not match the leaf certificate")
+	}
+
+	return leaf, nil
+}
+
+// parsePrivateKey parses a PEM encoded private key in PKCS #8, PKCS #1 or SEC
+// 1 format.
+func parsePrivateKey(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.New("no PEM encoded private key is found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, errors.New("failed to parse the private key; it must be in PKCS #8, PKCS #1 or SEC 1 format")
+}
+
+// certificateCipher returns the cipher of the certificate as represented in
+// the API.
+func certificateCipher(cert *x509.Certificate) (client.TlsCipher, error) {
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return client.Rsa, nil
+	case *ecdsa.PublicKey:
+		return client.Ec, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T; only RSA and ECDSA are supported", cert.PublicKey)
+	}
+}
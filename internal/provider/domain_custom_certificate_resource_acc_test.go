@@ -0,0 +1,157 @@
+package provider_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"terraform-provider-deno/client"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/thanhpk/randstr"
+)
+
+func TestAccDomainCustomCertificate(t *testing.T) {
+	if fakeServer == nil {
+		t.Skip("a self-signed certificate can only be uploaded to the fake server")
+	}
+
+	domainName := fmt.Sprintf("%s.example.com", randstr.String(16, letters))
+	otherDomainName := fmt.Sprintf("%s.example.com", randstr.String(16, letters))
+	notAfter := time.Now().Add(24 * time.Hour)
+	chain, key := generateTestCertificate(t, []string{domainName, otherDomainName}, notAfter)
+	outOfBandChain, outOfBandKey := generateTestCertificate(t, []string{domainName, otherDomainName}, notAfter.Add(time.Hour))
+	renewedChain, renewedKey := generateTestCertificate(t, []string{domainName, otherDomainName}, notAfter.Add(2*time.Hour))
+
+	config := func(domain string, chain string, key string) string {
+		return fmt.Sprintf(`
+			resource "deno_domain" "test" {
+				domain = "%s"
+			}
+
+			resource "deno_domain" "other" {
+				domain = "%s"
+			}
+
+			resource "deno_domain_custom_certificate" "test" {
+				domain_id         = deno_domain.%s.id
+				certificate_chain = <<-EOT
+%sEOT
+				private_key       = <<-EOT
+%sEOT
+			}
+		`, domainName, otherDomainName, domain, chain, key)
+	}
+
+	var domainID uuid.UUID
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDomainDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				// Uploading the certificate reports the cipher and the expiry
+				// of the leaf certificate
+				Config: config("test", chain, key),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("deno_domain_custom_certificate.test", "domain_id", "deno_domain.test", "id"),
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "cipher", "ec"),
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "expires_at", notAfter.UTC().Format(time.RFC3339)),
+					func(s *terraform.State) error {
+						var err error
+						domainID, err = uuid.Parse(s.RootModule().Resources["deno_domain.test"].Primary.ID)
+						return err
+					},
+				),
+			},
+			{
+				// Read picks up the certificate uploaded outside of Terraform
+				PreConfig: func() {
+					result, err := getAPIClient(t).AddDomainCertificateWithResponse(context.Background(), domainID, client.AddDomainCertificateRequest{
+						CertificateChain: outOfBandChain,
+						PrivateKey:       outOfBandKey,
+					})
+					if err == nil {
+						err = client.CheckResponse(result)
+					}
+					if err != nil {
+						t.Fatalf("failed to upload the certificate: %s", err)
+					}
+				},
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "cipher", "ec"),
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "expires_at", notAfter.Add(time.Hour).UTC().Format(time.RFC3339)),
+				),
+			},
+			{
+				// A renewed certificate is uploaded in place
+				Config: config("test", renewedChain, renewedKey),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("deno_domain_custom_certificate.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "cipher", "ec"),
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "expires_at", notAfter.Add(2*time.Hour).UTC().Format(time.RFC3339)),
+				),
+			},
+			{
+				// Changing the domain replaces the resource
+				Config: config("other", renewedChain, renewedKey),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("deno_domain_custom_certificate.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("deno_domain_custom_certificate.test", "domain_id", "deno_domain.other", "id"),
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "cipher", "ec"),
+					resource.TestCheckResourceAttr("deno_domain_custom_certificate.test", "expires_at", notAfter.Add(2*time.Hour).UTC().Format(time.RFC3339)),
+				),
+			},
+		},
+	})
+}
+
+// generateTestCertificate generates a self-signed ECDSA certificate for the
+// given DNS names, and returns the PEM encoded certificate and private key.
+func generateTestCertificate(t *testing.T, dnsNames []string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
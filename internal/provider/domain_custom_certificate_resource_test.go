@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCertificateChain(t *testing.T) {
+	caKey, caCert := generateCertificate(t, "Test CA", nil, nil, nil)
+	leafKey, leafCert := generateCertificate(t, "foo.example.com", []string{"foo.example.com"}, caKey, caCert)
+	otherKey, _ := generateCertificate(t, "other", nil, nil, nil)
+
+	tests := []struct {
+		name        string
+		chain       string
+		key         string
+		expectedErr string
+	}{
+		{
+			name:  "leaf only",
+			chain: encodeCertificate(leafCert),
+			key:   encodePrivateKey(t, leafKey),
+		},
+		{
+			name:  "leaf followed by CA",
+			chain: encodeCertificate(leafCert) + encodeCertificate(caCert),
+			key:   encodePrivateKey(t, leafKey),
+		},
+		{
+			name:        "empty chain",
+			chain:       "",
+			key:         encodePrivateKey(t, leafKey),
+			expectedErr: "no PEM encoded certificate",
+		},
+		{
+			name:        "wrong order",
+			chain:       encodeCertificate(caCert) + encodeCertificate(leafCert),
+			key:         encodePrivateKey(t, leafKey),
+			expectedErr: "must be ordered",
+		},
+		{
+			name:        "key mismatch",
+			chain:       encodeCertificate(leafCert) + encodeCertificate(caCert),
+			key:         encodePrivateKey(t, otherKey),
+			expectedErr: "does not match",
+		},
+		{
+			name:        "invalid key",
+			chain:       encodeCertificate(leafCert),
+			key:         "not a key",
+			expectedErr: "no PEM encoded private key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaf, err := parseCertificateChain(tt.chain, tt.key)
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("parseCertificateChain() error = %v, want error containing %q", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCertificateChain() returned error: %s", err)
+			}
+			if err := leaf.VerifyHostname("foo.example.com"); err != nil {
+				t.Errorf("parseCertificateChain() returned unexpected leaf: %s", err)
+			}
+		})
+	}
+}
+
+func generateCertificate(t *testing.T, commonName string, dnsNames []string, parentKey *ecdsa.PrivateKey, parent *x509.Certificate) (*ecdsa.PrivateKey, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent = template
+		parentKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, cert
+}
+
+func encodeCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func encodePrivateKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &domainDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainDataSource{}
+)
+
+var domainCertificateType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"cipher":     types.StringType,
+		"expires_at": types.StringType,
+	},
+}
+
+// domainObjectType is the type of each element of the domains list of
+// deno_domains, and the attributes of deno_domain.
+var domainObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":                   types.StringType,
+		"domain":               types.StringType,
+		"token":                types.StringType,
+		"is_validated":         types.BoolType,
+		"project_id":           types.StringType,
+		"provisioning_status":  types.StringType,
+		"provisioning_message": types.StringType,
+		"dns_records":          types.ListType{ElemType: dnsRecordType},
+		"certificates":         types.ListType{ElemType: domainCertificateType},
+		"created_at":           types.StringType,
+		"updated_at":           types.StringType,
+	},
+}
+
+// NewDomainDataSource is a helper function to simplify the provider implementation.
+func NewDomainDataSource() datasource.DataSource {
+	return &domainDataSource{}
+}
+
+// domainDataSource is the data source implementation.
+type domainDataSource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// domainDataSourceModel maps the data source schema data. It is also the
+// element of the domains list of deno_domains.
+type domainDataSourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Domain              types.String `tfsdk:"domain"`
+	Token               types.String `tfsdk:"token"`
+	IsValidated         types.Bool   `tfsdk:"is_validated"`
+	ProjectID           types.String `tfsdk:"project_id"`
+	ProvisioningStatus  types.String `tfsdk:"provisioning_status"`
+	ProvisioningMessage types.String `tfsdk:"provisioning_message"`
+	DNSRecords          types.List   `tfsdk:"dns_records"`
+	Certificates        types.List   `tfsdk:"certificates"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	UpdatedAt           types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *domainDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+// Schema defines the schema for the data source.
+func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := domainDataSourceAttributes()
+	attributes["domain"] = schema.StringAttribute{
+		Required:    true,
+		Description: "The custom domain to look up, such as `foo.example.com`.",
+	}
+
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for a custom domain in the organization, looked up by its hostname.
+
+This is useful for referring to a domain managed elsewhere, e.g. to add its DNS records in another Terraform workspace.
+		`,
+		Attributes: attributes,
+	}
+}
+
+// domainDataSourceAttributes returns the computed attributes of a domain,
+// shared by deno_domain and deno_domains.
+func domainDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the domain.",
+		},
+		"domain": schema.StringAttribute{
+			Computed:    true,
+			Description: "The custom domain, such as `foo.example.com`.",
+		},
+		"token": schema.StringAttribute{
+			Computed:    true,
+			Description: "The token used for verifying the ownership of the domain.",
+		},
+		"is_validated": schema.BoolAttribute{
+			Computed:    true,
+			Description: "Whether the ownership of the domain has been verified.",
+		},
+		"project_id": schema.StringAttribute{
+			Computed:    true,
+			Description: "The ID of the project that the domain is associated with. It is null if the domain is not associated with any project.",
+		},
+		"provisioning_status": schema.StringAttribute{
+			Computed:    true,
+			Description: "The status of the TLS certificate provisioning. It can be either `success`, `failed`, `pending` or `manual`.",
+		},
+		"provisioning_message": schema.StringAttribute{
+			Computed:    true,
+			Description: "The reason of the failure if `provisioning_status` is `failed`, and empty otherwise.",
+		},
+		"dns_records": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The DNS records that need to be added to the DNS nameserver.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Computed:    true,
+						Description: "The type of the DNS record such as `A`, `CNAME`, etc.",
+					},
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "The name of the DNS record.",
+					},
+					"content": schema.StringAttribute{
+						Computed:    true,
+						Description: "The content of the DNS record. The value depends on the type of the DNS record. For example, for `A` record, it is the IP address of the domain.",
+					},
+				},
+			},
+		},
+		"certificates": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "The TLS certificates of the domain.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"cipher": schema.StringAttribute{
+						Computed:    true,
+						Description: "The cipher of the certificate. It can be either `rsa` or `ec`.",
+					},
+					"expires_at": schema.StringAttribute{
+						Computed:            true,
+						Description:         "The time the certificate expires, formatted in RFC3339.",
+						MarkdownDescription: "The time the certificate expires, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+					},
+				},
+			},
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The time the domain was created, formatted in RFC3339.",
+			MarkdownDescription: "The time the domain was created, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+		},
+		"updated_at": schema.StringAttribute{
+			Computed:            true,
+			Description:         "The time the domain was last updated, formatted in RFC3339.",
+			MarkdownDescription: "The time the domain was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config domainDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, diag := findDomainByName(ctx, d.client, d.organizationID, config.Domain.ValueString())
+	if diag != nil {
+		resp.Diagnostics.Append(diag)
+		return
+	}
+
+	state, diags := newDomainDataSourceModel(*domain)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// newDomainDataSourceModel maps the domain returned by the API to the model.
+func newDomainDataSourceModel(domain client.Domain) (domainDataSourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	model := domainDataSourceModel{
+		ID:          types.StringValue(domain.Id.String()),
+		Domain:      types.StringValue(domain.Domain),
+		Token:       types.StringValue(domain.Token),
+		IsValidated: types.BoolValue(domain.IsValidated),
+		ProjectID:   types.StringNull(),
+		CreatedAt:   types.StringValue(domain.CreatedAt.Format(time.RFC3339)),
+		UpdatedAt:   types.StringValue(domain.UpdatedAt.Format(time.RFC3339)),
+	}
+	if domain.ProjectId != nil {
+		model.ProjectID = types.StringValue(domain.ProjectId.String())
+	}
+
+	status, message, err := provisioningStatusString(domain.ProvisioningStatus)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Failed to Get Provisioning Status of Domain %s", domain.Domain),
+			err.Error(),
+		)
+		return model, diags
+	}
+	model.ProvisioningStatus = types.StringValue(status)
+	model.ProvisioningMessage = types.StringValue(message)
+
+	dnsRecords, d := convertToDNSRecordsList(domain.DnsRecords)
+	diags.Append(d...)
+	model.DNSRecords = dnsRecords
+
+	certificates := make([]attr.Value, len(domain.Certificates))
+	for i, certificate := range domain.Certificates {
+		objectValue, d := types.ObjectValue(domainCertificateType.AttrTypes, map[string]attr.Value{
+			"cipher":     types.StringValue(string(certificate.Cipher)),
+			"expires_at": types.StringValue(certificate.ExpiresAt.Format(time.RFC3339)),
+		})
+		diags.Append(d...)
+		certificates[i] = objectValue
+	}
+	if diags.HasError() {
+		return model, diags
+	}
+	model.Certificates, d = types.ListValue(domainCertificateType, certificates)
+	diags.Append(d...)
+
+	return model, diags
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *domainDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+	d.organizationID = providerData.organizationID
+}
@@ -0,0 +1,56 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/thanhpk/randstr"
+)
+
+func TestAccDomainDataSources(t *testing.T) {
+	domainName := fmt.Sprintf("%s.example.com", randstr.String(16, letters))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "deno_domain" "test" {
+						domain = "%s"
+					}
+
+					data "deno_domain" "test" {
+						domain = deno_domain.test.domain
+					}
+
+					data "deno_domains" "test" {
+						depends_on = [deno_domain.test]
+					}
+				`, domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.deno_domain.test", "id", "deno_domain.test", "id"),
+					resource.TestCheckResourceAttrPair("data.deno_domain.test", "token", "deno_domain.test", "token"),
+					resource.TestCheckResourceAttrPair("data.deno_domain.test", "dns_records.#", "deno_domain.test", "dns_records.#"),
+					resource.TestCheckResourceAttr("data.deno_domain.test", "is_validated", "false"),
+					resource.TestCheckNoResourceAttr("data.deno_domain.test", "project_id"),
+					resource.TestCheckResourceAttrSet("data.deno_domain.test", "provisioning_status"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.deno_domains.test", "domains.*", map[string]string{
+						"domain":       domainName,
+						"is_validated": "false",
+					}),
+				),
+			},
+			{
+				Config: `
+					data "deno_domain" "test" {
+						domain = "does-not-exist.example.com"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`No domain named`),
+			},
+		},
+	})
+}
@@ -9,17 +9,20 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = &domainResource{}
-	_ resource.ResourceWithConfigure = &domainResource{}
+	_ resource.Resource                = &domainResource{}
+	_ resource.ResourceWithConfigure   = &domainResource{}
+	_ resource.ResourceWithImportState = &domainResource{}
 )
 
 // NewDomainResource is a helper function to simplify the provider implementation.
@@ -131,10 +134,14 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 		)
 		return
 	}
-	if client.RespIsError(domain) {
+	if err := client.CheckResponse(domain); err != nil {
+		detail := apiErrorDetail(err)
+		if client.IsConflict(err) {
+			detail = fmt.Sprintf("The domain %s has already been added to Deno Deploy. If it belongs to this organization, import it with `terraform import` instead. Otherwise, remove it from the other organization first.\n\n%s", plan.Domain.ValueString(), detail)
+		}
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Create Domain %s", plan.Domain.ValueString()),
-			client.APIErrorDetail(domain.HTTPResponse, domain.Body),
+			detail,
 		)
 		return
 	}
@@ -161,16 +168,15 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 }
 
-func convertToDNSRecordsList(dnsRecords []client.DnsRecord) (types.List, diag.Diagnostics) {
-	dnsRecordType := map[string]attr.Type{
+var dnsRecordType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
 		"type":    types.StringType,
 		"name":    types.StringType,
 		"content": types.StringType,
-	}
-	ty := types.ObjectType{
-		AttrTypes: dnsRecordType,
-	}
+	},
+}
 
+func convertToDNSRecordsList(dnsRecords []client.DnsRecord) (types.List, diag.Diagnostics) {
 	records := make([]attr.Value, len(dnsRecords))
 	for i, dnsRecord := range dnsRecords {
 		elements := map[string]attr.Value{
@@ -178,16 +184,16 @@ func convertToDNSRecordsList(dnsRecords []client.DnsRecord) (types.List, diag.Di
 			"name":    types.StringValue(dnsRecord.Name),
 			"content": types.StringValue(dnsRecord.Content),
 		}
-		objectValue, diags := types.ObjectValue(dnsRecordType, elements)
+		objectValue, diags := types.ObjectValue(dnsRecordType.AttrTypes, elements)
 		if diags.HasError() {
-			return types.ListNull(ty), diags
+			return types.ListNull(dnsRecordType), diags
 		}
 		records[i] = objectValue
 	}
 
-	dnsRecordsList, diags := types.ListValue(ty, records)
+	dnsRecordsList, diags := types.ListValue(dnsRecordType, records)
 	if diags.HasError() {
-		return types.ListNull(ty), diags
+		return types.ListNull(dnsRecordType), diags
 	}
 
 	return dnsRecordsList, nil
@@ -221,10 +227,18 @@ func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, res
 		)
 		return
 	}
-	if domain.StatusCode() != 200 {
+	if err := client.CheckResponse(domain); err != nil {
+		if client.IsNotFound(err) {
+			// The domain has been deleted outside of Terraform
+			tflog.Warn(ctx, "Domain not found, removing it from the state", map[string]any{
+				"domain_id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Domain %s", state.ID),
-			client.APIErrorDetail(domain.HTTPResponse, domain.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
@@ -280,10 +294,10 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
-	if result.StatusCode() != 200 {
+	if err := client.CheckResponse(result); err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to delete Domain %s", plan.ID),
-			client.APIErrorDetail(result.HTTPResponse, result.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
@@ -299,10 +313,14 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		)
 		return
 	}
-	if client.RespIsError(domain) {
+	if err := client.CheckResponse(domain); err != nil {
+		detail := apiErrorDetail(err)
+		if client.IsConflict(err) {
+			detail = fmt.Sprintf("The domain %s has already been added to Deno Deploy. If it belongs to this organization, import it with `terraform import` instead. Otherwise, remove it from the other organization first.\n\n%s", plan.Domain.ValueString(), detail)
+		}
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Update Domain %s", plan.Domain.ValueString()),
-			client.APIErrorDetail(domain.HTTPResponse, domain.Body),
+			detail,
 		)
 		return
 	}
@@ -357,15 +375,34 @@ func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		)
 		return
 	}
-	if result.StatusCode() != 200 {
+	if err := client.CheckResponse(result); err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Delete Domain %s", state.ID),
-			client.APIErrorDetail(result.HTTPResponse, result.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
 }
 
+// ImportState imports the existing resource into Terraform. The import ID can
+// be either the ID of the domain or the domain name, such as `foo.example.com`.
+func (r *domainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if _, err := uuid.Parse(req.ID); err == nil {
+		// Retrieve import ID and save to id attribute
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	// Otherwise, treat the import ID as a domain name
+	domain, d := findDomainByName(ctx, r.client, r.organizationID, req.ID)
+	if d != nil {
+		resp.Diagnostics.Append(d)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), domain.Id.String())...)
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -386,3 +423,25 @@ func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = providerData.client
 	r.organizationID = providerData.organizationID
 }
+
+// findDomainByName looks up the domain with the given name in the
+// organization, going through all the pages of the domain list.
+func findDomainByName(ctx context.Context, c client.ClientWithResponsesInterface, organizationID uuid.UUID, name string) (*client.Domain, diag.Diagnostic) {
+	domains := client.ListAllDomains(ctx, c, organizationID)
+	for domains.Next() {
+		if domain := domains.Value(); domain.Domain == name {
+			return &domain, nil
+		}
+	}
+	if err := domains.Err(); err != nil {
+		return nil, diag.NewErrorDiagnostic(
+			fmt.Sprintf("Unable to Find Domain %s", name),
+			fmt.Sprintf("ListDomains API returned error: %s", apiErrorDetail(err)),
+		)
+	}
+
+	return nil, diag.NewErrorDiagnostic(
+		fmt.Sprintf("Unable to Find Domain %s", name),
+		fmt.Sprintf("No domain named %s was found in the organization %s.", name, organizationID),
+	)
+}
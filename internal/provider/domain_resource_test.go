@@ -0,0 +1,116 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-deno/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/thanhpk/randstr"
+)
+
+func TestAccDomain(t *testing.T) {
+	domainName := fmt.Sprintf("%s.example.com", randstr.String(16, letters))
+	config := fmt.Sprintf(`
+		resource "deno_domain" "test" {
+			domain = "%s"
+		}
+	`, domainName)
+	var domainID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccDomainDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("deno_domain.test", "domain", domainName),
+					resource.TestCheckResourceAttrSet("deno_domain.test", "token"),
+					func(s *terraform.State) error {
+						domainID = s.RootModule().Resources["deno_domain.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Import by ID
+				ResourceName:      "deno_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Import by domain name
+				ResourceName:      "deno_domain.test",
+				ImportState:       true,
+				ImportStateId:     domainName,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "deno_domain.test",
+				ImportState:   true,
+				ImportStateId: fmt.Sprintf("missing-%s", domainName),
+				ExpectError:   regexp.MustCompile(`Unable to Find Domain`),
+			},
+			{
+				// A domain deleted outside of Terraform is removed from the
+				// state and created again
+				PreConfig: func() {
+					resp, err := getAPIClient(t).DeleteDomainWithResponse(context.Background(), uuid.MustParse(domainID))
+					if err == nil {
+						err = client.CheckResponse(resp)
+					}
+					if err != nil {
+						t.Fatalf("failed to delete domain %s: %s", domainID, err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("deno_domain.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("deno_domain.test", "domain", domainName),
+					resource.TestCheckResourceAttrWith("deno_domain.test", "id", func(id string) error {
+						if id == domainID {
+							return fmt.Errorf("expected a new domain, got the deleted one %s", id)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDomainDestroy(t *testing.T) func(*terraform.State) error {
+	client := getAPIClient(t)
+
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "deno_domain" {
+				continue
+			}
+			domainID, err := uuid.Parse(rs.Primary.ID)
+			if err != nil {
+				return fmt.Errorf("failed to parse domain id: %s", err)
+			}
+			resp, err := client.GetDomainWithResponse(context.Background(), domainID)
+			if err != nil {
+				return fmt.Errorf("failed to get domain: %s", err)
+			}
+			if resp.JSON404 == nil {
+				return fmt.Errorf("domain still exists: %s", rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
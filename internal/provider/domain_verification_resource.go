@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -120,7 +121,7 @@ Polling:
 				)
 				return
 			}
-			if client.RespIsError(result) {
+			if client.CheckResponse(result) != nil {
 				continue
 			}
 
@@ -168,7 +169,15 @@ func (r *domainVerificationResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
-	if client.RespIsError(result) {
+	if err := client.CheckResponse(result); err != nil {
+		if client.IsNotFound(err) {
+			// The domain has been deleted outside of Terraform
+			tflog.Warn(ctx, "Domain not found, removing it from the state", map[string]any{
+				"domain_id": state.DomainID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		state.Verified = types.BoolValue(false)
 	} else {
 		state.Verified = types.BoolValue(true)
@@ -238,7 +247,7 @@ Polling:
 				)
 				return
 			}
-			if client.RespIsError(result) {
+			if client.CheckResponse(result) != nil {
 				continue
 			}
 
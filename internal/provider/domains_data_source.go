@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"terraform-provider-deno/client"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &domainsDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainsDataSource{}
+)
+
+// NewDomainsDataSource is a helper function to simplify the provider implementation.
+func NewDomainsDataSource() datasource.DataSource {
+	return &domainsDataSource{}
+}
+
+// domainsDataSource is the data source implementation.
+type domainsDataSource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// domainsDataSourceModel maps the data source schema data.
+type domainsDataSourceModel struct {
+	Domains types.List `tfsdk:"domains"`
+}
+
+// Metadata returns the data source type name.
+func (d *domainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains"
+}
+
+// Schema defines the schema for the data source.
+func (d *domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for all the custom domains in the organization.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"domains": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The custom domains, sorted by domain name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: domainDataSourceAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	domains, err := client.ListAllDomains(ctx, d.client, d.organizationID).All()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Domains",
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	// Domain names are unique, so they give a stable order
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].Domain < domains[j].Domain
+	})
+
+	elems := make([]attr.Value, len(domains))
+	for i, domain := range domains {
+		model, diags := newDomainDataSourceModel(domain)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		objectValue, diags := types.ObjectValueFrom(ctx, domainObjectType.AttrTypes, model)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elems[i] = objectValue
+	}
+
+	list, diags := types.ListValue(domainObjectType, elems)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := domainsDataSourceModel{
+		Domains: list,
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *domainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+	d.organizationID = providerData.organizationID
+}
@@ -0,0 +1,17 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+// SetCertificateProvisioningPollingInterval shortens the interval between
+// status checks while waiting for certificate provisioning, until the test
+// finishes.
+func SetCertificateProvisioningPollingInterval(t *testing.T, interval time.Duration) {
+	original := certificateProvisioningPollingInterval
+	certificateProvisioningPollingInterval = interval
+	t.Cleanup(func() {
+		certificateProvisioningPollingInterval = original
+	})
+}
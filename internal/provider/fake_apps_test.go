@@ -0,0 +1,86 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"terraform-provider-deno/internal/fakedeploy"
+)
+
+// cowsayHello is what cowsay says for "Hello", which testdata/lockfile/main.ts
+// responds with.
+const cowsayHello = ` _______
+< Hello >
+ -------
+        \   ^__^
+         \  (oo)\_______
+            (__)\       )\/\
+                ||----w |
+                ||     ||`
+
+// serveTestApps registers to the fake server the apps emulating the entry
+// points in testdata, so that the tests can check the responses of their
+// deployments. Each app responds like the entry point would, as long as the
+// assets it depends on are deployed.
+func serveTestApps(s *fakedeploy.Server) {
+	hello := func(message string) fakedeploy.App {
+		return func(app fakedeploy.DeployedApp) ([]byte, error) {
+			return []byte(message), nil
+		}
+	}
+	s.ServeApp("testdata/single-file/main.ts", hello("Hello world"))
+	s.ServeApp("testdata/tsx/main.tsx", hello("<h1>Hello World!</h1>"))
+	s.ServeApp("testdata/config_auto_discovery/main.tsx", hello("<h1>Hello World!</h1>"))
+	s.ServeApp("testdata/import_map/main.ts", hello("Hello World"))
+	s.ServeApp("testdata/lockfile/main.ts", hello(cowsayHello))
+
+	s.ServeApp("testdata/env_var/main.ts", func(app fakedeploy.DeployedApp) ([]byte, error) {
+		return []byte(fmt.Sprintf("Hello %s", app.EnvVars["FOO"])), nil
+	})
+
+	sum := func(app fakedeploy.DeployedApp) ([]byte, error) {
+		dir := path.Dir(app.EntryPoint)
+		if _, err := requireAsset(app, path.Join(dir, "util/calc.ts")); err != nil {
+			return nil, err
+		}
+		content, err := requireAsset(app, path.Join(dir, "operands.json"))
+		if err != nil {
+			return nil, err
+		}
+		var operands []float64
+		if err := json.Unmarshal(content, &operands); err != nil || len(operands) != 2 {
+			return nil, fmt.Errorf("invalid operands: %s", content)
+		}
+		return []byte(fmt.Sprintf("sum: %g", operands[0]+operands[1])), nil
+	}
+	s.ServeApp("testdata/multi-file/main.ts", sum)
+	// TestAccDeployment_RootDir deploys testdata/multi-file as the root
+	s.ServeApp("main.ts", sum)
+
+	s.ServeApp("testdata/symlink/main.ts", func(app fakedeploy.DeployedApp) ([]byte, error) {
+		if _, err := requireAsset(app, path.Join(path.Dir(app.EntryPoint), "symlink.js")); err != nil {
+			return nil, err
+		}
+		return []byte("sum: 42"), nil
+	})
+
+	s.ServeApp("testdata/binary/main.ts", func(app fakedeploy.DeployedApp) ([]byte, error) {
+		return requireAsset(app, path.Join(path.Dir(app.EntryPoint), "computer_screen_programming.png"))
+	})
+}
+
+// requireAsset returns the content of the file deployed at the given path,
+// following symlinks, or an error if it is missing.
+func requireAsset(app fakedeploy.DeployedApp, name string) ([]byte, error) {
+	for i := 0; i < 8; i++ {
+		if content, ok := app.Files[name]; ok {
+			return content, nil
+		}
+		target, ok := app.Symlinks[name]
+		if !ok {
+			return nil, fmt.Errorf("module not found: %s", name)
+		}
+		name = path.Join(path.Dir(name), target)
+	}
+	return nil, fmt.Errorf("too many levels of symbolic links: %s", name)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &organizationDataSource{}
+	_ datasource.DataSourceWithConfigure = &organizationDataSource{}
+)
+
+// NewOrganizationDataSource is a helper function to simplify the provider implementation.
+func NewOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{}
+}
+
+// organizationDataSource is the data source implementation.
+type organizationDataSource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// organizationDataSourceModel maps the data source schema data.
+type organizationDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *organizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+// Schema defines the schema for the data source.
+func (d *organizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for the organization the provider is configured with.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the organization.",
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the organization.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the organization was created, formatted in RFC3339.",
+				MarkdownDescription: "The time the organization was created, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the organization was last updated, formatted in RFC3339.",
+				MarkdownDescription: "The time the organization was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	org, err := d.client.GetOrganizationWithResponse(ctx, d.organizationID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Organization %s", d.organizationID),
+			err.Error(),
+		)
+		return
+	}
+	if err := client.CheckResponse(org); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Organization %s", d.organizationID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	state := organizationDataSourceModel{
+		ID:        types.StringValue(org.JSON200.Id.String()),
+		Name:      types.StringValue(org.JSON200.Name),
+		CreatedAt: types.StringValue(org.JSON200.CreatedAt.Format(time.RFC3339)),
+		UpdatedAt: types.StringValue(org.JSON200.UpdatedAt.Format(time.RFC3339)),
+	}
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *organizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+	d.organizationID = providerData.organizationID
+}
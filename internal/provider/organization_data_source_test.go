@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccOrganizationDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "deno" {
+						validate_credentials = true
+					}
+
+					data "deno_organization" "test" {}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.deno_organization.test", "id", os.Getenv("DENO_DEPLOY_ORGANIZATION_ID")),
+					resource.TestCheckResourceAttrSet("data.deno_organization.test", "name"),
+					resource.TestCheckResourceAttrSet("data.deno_organization.test", "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProvider_ValidateCredentials(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "deno" {
+						token                = "ddp_invalid_token"
+						validate_credentials = true
+					}
+
+					data "deno_organization" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Deno Deploy API Token`),
+			},
+			{
+				Config: `
+					provider "deno" {
+						organization_id      = "00000000-0000-0000-0000-000000000000"
+						validate_credentials = true
+					}
+
+					data "deno_organization" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Inaccessible Deno Deploy Organization`),
+			},
+		},
+	})
+}
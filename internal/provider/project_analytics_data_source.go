@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &projectAnalyticsDataSource{}
+	_ datasource.DataSourceWithConfigure = &projectAnalyticsDataSource{}
+)
+
+// analyticsFieldType is the type of each element of the fields list.
+var analyticsFieldType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":          types.StringType,
+		"type":          types.StringType,
+		"time_values":   types.ListType{ElemType: types.StringType},
+		"number_values": types.ListType{ElemType: types.Float64Type},
+		"string_values": types.ListType{ElemType: types.StringType},
+		"bool_values":   types.ListType{ElemType: types.BoolType},
+	},
+}
+
+// analyticsAggregateType is the type of each element of the aggregates map.
+var analyticsAggregateType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"count":   types.Int64Type,
+		"sum":     types.Float64Type,
+		"max":     types.Float64Type,
+		"average": types.Float64Type,
+	},
+}
+
+// NewProjectAnalyticsDataSource is a helper function to simplify the provider implementation.
+func NewProjectAnalyticsDataSource() datasource.DataSource {
+	return &projectAnalyticsDataSource{}
+}
+
+// projectAnalyticsDataSource is the data source implementation.
+type projectAnalyticsDataSource struct {
+	client client.ClientWithResponsesInterface
+}
+
+// projectAnalyticsDataSourceModel maps the data source schema data.
+type projectAnalyticsDataSourceModel struct {
+	ProjectID  types.String `tfsdk:"project_id"`
+	RowCount   types.Int64  `tfsdk:"row_count"`
+	Fields     types.List   `tfsdk:"fields"`
+	Aggregates types.Map    `tfsdk:"aggregates"`
+}
+
+// Metadata returns the data source type name.
+func (d *projectAnalyticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_analytics"
+}
+
+// Schema defines the schema for the data source.
+func (d *projectAnalyticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for the analytics of a project, such as the number of requests and the bandwidth over time.
+
+The API returns the analytics as a table whose columns are called fields. Each field is decoded according to its type into one of the typed lists, and the numeric fields are summarized in ` + "`aggregates`" + `. This is useful for feeding dashboards and alert thresholds from Terraform outputs.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the project.",
+			},
+			"row_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of rows, i.e. the number of values in each field.",
+			},
+			"fields": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The fields, i.e. the columns of the analytics, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the field.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "The type of the field. It can be either `time`, `number`, `string`, `boolean` or `other`.",
+						},
+						"time_values": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							Description:         "The values of the field formatted in RFC3339, if its type is `time`. It is null otherwise.",
+							MarkdownDescription: "The values of the field formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339), if its type is `time`. It is null otherwise.",
+						},
+						"number_values": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.Float64Type,
+							Description: "The values of the field, if its type is `number`. It is null otherwise.",
+						},
+						"string_values": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "The values of the field, if its type is `string`. For a field of type `other`, the values are JSON encoded. It is null otherwise.",
+						},
+						"bool_values": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.BoolType,
+							Description: "The values of the field, if its type is `boolean`. It is null otherwise.",
+						},
+					},
+				},
+			},
+			"aggregates": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "The summary of each field of type `number`, keyed by the field name. Missing values are ignored.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "The number of values that are not missing.",
+						},
+						"sum": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The sum of the values.",
+						},
+						"max": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The maximum of the values. It is null if there are no values.",
+						},
+						"average": schema.Float64Attribute{
+							Computed:    true,
+							Description: "The average of the values. It is null if there are no values.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *projectAnalyticsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state projectAnalyticsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID, err := uuid.Parse(state.ProjectID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project_id"),
+			fmt.Sprintf("Unable to Read Analytics of Project %s", state.ProjectID),
+			fmt.Sprintf("Could not parse project ID %s: %s", state.ProjectID, err.Error()),
+		)
+		return
+	}
+
+	analytics, err := d.client.GetProjectAnalyticsWithResponse(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Analytics of Project %s", state.ProjectID),
+			err.Error(),
+		)
+		return
+	}
+	if err := client.CheckResponse(analytics); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unable to Read Analytics of Project %s", state.ProjectID),
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	fields, aggregates, diags := convertAnalytics(*analytics.JSON200)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.RowCount = types.Int64Value(int64(len(analytics.JSON200.Values)))
+	state.Fields = fields
+	state.Aggregates = aggregates
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// convertAnalytics decodes each column of the analytics according to the type
+// of its field, and summarizes the numeric columns. The values are given row
+// by row, and missing values are represented by JSON nulls.
+func convertAnalytics(analytics client.Analytics) (types.List, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	fieldsNull := types.ListNull(analyticsFieldType)
+	aggregatesNull := types.MapNull(analyticsAggregateType)
+
+	for i, row := range analytics.Values {
+		if len(row) != len(analytics.Fields) {
+			diags.AddError(
+				"Unable to Decode Analytics",
+				fmt.Sprintf("Row %d has %d values, but there are %d fields.", i, len(row), len(analytics.Fields)),
+			)
+			return fieldsNull, aggregatesNull, diags
+		}
+	}
+
+	fields := make([]attr.Value, len(analytics.Fields))
+	aggregates := map[string]attr.Value{}
+	for col, field := range analytics.Fields {
+		values := map[string]attr.Value{
+			"name":          types.StringValue(field.Name),
+			"type":          types.StringValue(string(field.Type)),
+			"time_values":   types.ListNull(types.StringType),
+			"number_values": types.ListNull(types.Float64Type),
+			"string_values": types.ListNull(types.StringType),
+			"bool_values":   types.ListNull(types.BoolType),
+		}
+
+		var numbers []*float64
+		elems := make([]attr.Value, len(analytics.Values))
+		for row := range analytics.Values {
+			elem, number, err := decodeAnalyticsValue(field.Type, analytics.Values[row][col])
+			if err != nil {
+				diags.AddError(
+					"Unable to Decode Analytics",
+					fmt.Sprintf("Could not decode the value of field %s in row %d as %s: %s", field.Name, row, field.Type, err.Error()),
+				)
+				return fieldsNull, aggregatesNull, diags
+			}
+			elems[row] = elem
+			if field.Type == client.Number {
+				numbers = append(numbers, number)
+			}
+		}
+
+		var d diag.Diagnostics
+		switch field.Type {
+		case client.Time:
+			values["time_values"], d = types.ListValue(types.StringType, elems)
+		case client.Number:
+			values["number_values"], d = types.ListValue(types.Float64Type, elems)
+		case client.Boolean:
+			values["bool_values"], d = types.ListValue(types.BoolType, elems)
+		default:
+			values["string_values"], d = types.ListValue(types.StringType, elems)
+		}
+		diags.Append(d...)
+		if diags.HasError() {
+			return fieldsNull, aggregatesNull, diags
+		}
+
+		fields[col], d = types.ObjectValue(analyticsFieldType.AttrTypes, values)
+		diags.Append(d...)
+		if diags.HasError() {
+			return fieldsNull, aggregatesNull, diags
+		}
+
+		if field.Type != client.Number {
+			continue
+		}
+		if _, ok := aggregates[field.Name]; ok {
+			diags.AddError(
+				"Unable to Decode Analytics",
+				fmt.Sprintf("There are more than one numeric fields named %s.", field.Name),
+			)
+			return fieldsNull, aggregatesNull, diags
+		}
+		aggregates[field.Name], d = aggregateAnalyticsNumbers(numbers)
+		diags.Append(d...)
+		if diags.HasError() {
+			return fieldsNull, aggregatesNull, diags
+		}
+	}
+
+	fieldsList, d := types.ListValue(analyticsFieldType, fields)
+	diags.Append(d...)
+	if diags.HasError() {
+		return fieldsNull, aggregatesNull, diags
+	}
+	aggregatesMap, d := types.MapValue(analyticsAggregateType, aggregates)
+	diags.Append(d...)
+	if diags.HasError() {
+		return fieldsNull, aggregatesNull, diags
+	}
+
+	return fieldsList, aggregatesMap, diags
+}
+
+// decodeAnalyticsValue decodes a value of the given field type into a
+// Terraform value. For a number, the decoded number is returned as well so
+// that it can be aggregated. A missing value is decoded into a null.
+func decodeAnalyticsValue(fieldType client.AnalyticsFieldType, value client.AnalyticsDataValue) (attr.Value, *float64, error) {
+	raw, err := value.MarshalJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	isNull := string(raw) == "null"
+
+	switch fieldType {
+	case client.Time:
+		if isNull {
+			return types.StringNull(), nil, nil
+		}
+		t, err := value.AsAnalyticsDataValue0()
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.StringValue(t.Format(time.RFC3339Nano)), nil, nil
+	case client.Number:
+		if isNull {
+			return types.Float64Null(), nil, nil
+		}
+		n, err := value.AsAnalyticsDataValue1()
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.Float64Value(n), &n, nil
+	case client.String:
+		if isNull {
+			return types.StringNull(), nil, nil
+		}
+		s, err := value.AsAnalyticsDataValue2()
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.StringValue(s), nil, nil
+	case client.Boolean:
+		if isNull {
+			return types.BoolNull(), nil, nil
+		}
+		b, err := value.AsAnalyticsDataValue3()
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.BoolValue(b), nil, nil
+	default:
+		// The values of unknown types are kept as they are
+		if isNull {
+			return types.StringNull(), nil, nil
+		}
+		return types.StringValue(string(raw)), nil, nil
+	}
+}
+
+// aggregateAnalyticsNumbers summarizes the values of a numeric field, ignoring
+// the missing ones.
+func aggregateAnalyticsNumbers(numbers []*float64) (attr.Value, diag.Diagnostics) {
+	count := 0
+	sum := 0.0
+	maximum := math.Inf(-1)
+	for _, n := range numbers {
+		if n == nil {
+			continue
+		}
+		count++
+		sum += *n
+		maximum = max(maximum, *n)
+	}
+
+	maxValue := types.Float64Null()
+	average := types.Float64Null()
+	if count > 0 {
+		maxValue = types.Float64Value(maximum)
+		average = types.Float64Value(sum / float64(count))
+	}
+
+	return types.ObjectValue(analyticsAggregateType.AttrTypes, map[string]attr.Value{
+		"count":   types.Int64Value(int64(count)),
+		"sum":     types.Float64Value(sum),
+		"max":     maxValue,
+		"average": average,
+	})
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *projectAnalyticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+}
@@ -0,0 +1,89 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"terraform-provider-deno/client"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectAnalyticsDataSource(t *testing.T) {
+	if fakeServer == nil {
+		t.Skip("analytics can only be seeded on the fake server")
+	}
+
+	var analytics client.Analytics
+	err := json.Unmarshal([]byte(`{
+		"fields": [
+			{"name": "time", "type": "time"},
+			{"name": "requests", "type": "number"},
+			{"name": "region", "type": "string"},
+			{"name": "cached", "type": "boolean"}
+		],
+		"values": [
+			["2023-10-01T00:00:00Z", 10, "gcp-us-east1", true],
+			["2023-10-01T00:15:00Z", null, null, null],
+			["2023-10-01T00:30:00.123Z", 20.5, "gcp-europe-west2", false]
+		]
+	}`), &analytics)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	projectConfig := `
+		resource "deno_project" "test" {}
+	`
+	var projectID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccProjectDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: projectConfig,
+				Check: func(s *terraform.State) error {
+					projectID = s.RootModule().Resources["deno_project.test"].Primary.ID
+					return nil
+				},
+			},
+			{
+				PreConfig: func() {
+					fakeServer.SetAnalytics(uuid.MustParse(projectID), analytics)
+				},
+				Config: projectConfig + `
+					data "deno_project_analytics" "test" {
+						project_id = deno_project.test.id
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "row_count", "3"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "fields.#", "4"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "fields.0.type", "time"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "fields.0.time_values.2", "2023-10-01T00:30:00.123Z"),
+					resource.TestCheckNoResourceAttr("data.deno_project_analytics.test", "fields.0.number_values"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "fields.1.number_values.0", "10"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "fields.2.string_values.2", "gcp-europe-west2"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "fields.3.bool_values.0", "true"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "aggregates.%", "1"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "aggregates.requests.count", "2"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "aggregates.requests.sum", "30.5"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "aggregates.requests.max", "20.5"),
+					resource.TestCheckResourceAttr("data.deno_project_analytics.test", "aggregates.requests.average", "15.25"),
+				),
+			},
+			{
+				Config: `
+					data "deno_project_analytics" "test" {
+						project_id = "00000000-0000-0000-0000-000000000000"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Unable to Read Analytics of Project`),
+			},
+		},
+	})
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &projectDataSource{}
+	_ datasource.DataSourceWithConfigure      = &projectDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &projectDataSource{}
+)
+
+// NewProjectDataSource is a helper function to simplify the provider implementation.
+func NewProjectDataSource() datasource.DataSource {
+	return &projectDataSource{}
+}
+
+// projectDataSource is the data source implementation.
+type projectDataSource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// projectDataSourceModel maps the data source schema data.
+type projectDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// Metadata returns the data source type name.
+func (d *projectDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+// Schema defines the schema for the data source.
+func (d *projectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for an existing Deno Deploy project in the organization.
+
+The project is looked up by either its ID or its name. This is useful for referring to a project managed elsewhere, e.g. in another Terraform workspace.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the project. Exactly one of `id` and `name` must be set.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the project. Exactly one of `id` and `name` must be set.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the project was created, formatted in RFC3339.",
+				MarkdownDescription: "The time the project was created, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				Description:         "The time the project was last updated, formatted in RFC3339.",
+				MarkdownDescription: "The time the project was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+		},
+	}
+}
+
+// ValidateConfig ensures that exactly one of id and name is set.
+func (d *projectDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config projectDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values may not be known yet, e.g. when referring to another resource
+	if config.ID.IsUnknown() || config.Name.IsUnknown() {
+		return
+	}
+	if config.ID.IsNull() == config.Name.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Invalid Project Lookup",
+			"Exactly one of `id` and `name` must be set to look up a project.",
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config projectDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var project *client.Project
+	if !config.ID.IsNull() {
+		projID, err := uuid.Parse(config.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				fmt.Sprintf("Unable to Read Project %s", config.ID),
+				fmt.Sprintf("Could not parse project ID %s: %s", config.ID, err.Error()),
+			)
+			return
+		}
+
+		proj, err := d.client.GetProjectWithResponse(ctx, projID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Project %s", config.ID),
+				err.Error(),
+			)
+			return
+		}
+		if err := client.CheckResponse(proj); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Unable to Read Project %s", config.ID),
+				apiErrorDetail(err),
+			)
+			return
+		}
+		project = proj.JSON200
+	} else {
+		proj, diag := findProjectByName(ctx, d.client, d.organizationID, config.Name.ValueString())
+		if diag != nil {
+			resp.Diagnostics.Append(diag)
+			return
+		}
+		project = proj
+	}
+
+	state := projectDataSourceModel{
+		ID:        types.StringValue(project.Id.String()),
+		Name:      types.StringValue(project.Name),
+		CreatedAt: types.StringValue(project.CreatedAt.Format(time.RFC3339)),
+		UpdatedAt: types.StringValue(project.UpdatedAt.Format(time.RFC3339)),
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *projectDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+	d.organizationID = providerData.organizationID
+}
@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectDataSource(t *testing.T) {
+	projName := randomProjectName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccProjectDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "deno_project" "test" {
+						name = "%s"
+					}
+
+					data "deno_project" "by_id" {
+						id = deno_project.test.id
+					}
+
+					data "deno_project" "by_name" {
+						name = deno_project.test.name
+					}
+				`, projName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.deno_project.by_id", "name", "deno_project.test", "name"),
+					resource.TestCheckResourceAttrPair("data.deno_project.by_id", "created_at", "deno_project.test", "created_at"),
+					resource.TestCheckResourceAttrPair("data.deno_project.by_name", "id", "deno_project.test", "id"),
+					resource.TestCheckResourceAttrPair("data.deno_project.by_name", "updated_at", "deno_project.test", "updated_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectDataSource_NotFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "deno_project" "test" {
+						name = "%s"
+					}
+				`, randomProjectName()),
+				ExpectError: regexp.MustCompile(`No project named`),
+			},
+			{
+				Config: `
+					data "deno_project" "test" {}
+				`,
+				ExpectError: regexp.MustCompile(`Exactly one of`),
+			},
+		},
+	})
+}
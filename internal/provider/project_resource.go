@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -77,6 +79,7 @@ A project consists of a collection of deployments and belongs to an organization
 			},
 			"updated_at": schema.StringAttribute{
 				Computed:            true,
+				Description:         "The time the project was last updated, formatted in RFC3339.",
 				MarkdownDescription: "The time the project was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
 			},
 		},
@@ -112,10 +115,14 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		)
 		return
 	}
-	if client.RespIsError(proj) {
+	if err := client.CheckResponse(proj); err != nil {
+		detail := apiErrorDetail(err)
+		if client.IsConflict(err) {
+			detail = fmt.Sprintf("The project name %s is already taken. Project names are unique across Deno Deploy, so choose another name.\n\n%s", projName, detail)
+		}
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Create Project %s", projName),
-			client.APIErrorDetail(proj.HTTPResponse, proj.Body),
+			detail,
 		)
 		return
 	}
@@ -162,10 +169,18 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		)
 		return
 	}
-	if client.RespIsError(proj) {
+	if err := client.CheckResponse(proj); err != nil {
+		if client.IsNotFound(err) {
+			// The project has been deleted outside of Terraform
+			tflog.Warn(ctx, "Project not found, removing it from the state", map[string]any{
+				"project_id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Read Project %s", state.ID),
-			client.APIErrorDetail(proj.HTTPResponse, proj.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
@@ -214,10 +229,14 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		)
 		return
 	}
-	if client.RespIsError(proj) {
+	if err := client.CheckResponse(proj); err != nil {
+		detail := apiErrorDetail(err)
+		if client.IsConflict(err) {
+			detail = fmt.Sprintf("The project name %s is already taken. Project names are unique across Deno Deploy, so choose another name.\n\n%s", plan.Name.ValueString(), detail)
+		}
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Update Project %s", plan.ID),
-			client.APIErrorDetail(proj.HTTPResponse, proj.Body),
+			detail,
 		)
 		return
 	}
@@ -261,10 +280,10 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		)
 		return
 	}
-	if client.RespIsError(result) {
+	if err := client.CheckResponse(result); err != nil {
 		resp.Diagnostics.AddError(
 			fmt.Sprintf("Unable to Delete Project %s", state.ID),
-			client.APIErrorDetail(result.HTTPResponse, result.Body),
+			apiErrorDetail(err),
 		)
 		return
 	}
@@ -296,3 +315,25 @@ func (r *projectResource) Configure(_ context.Context, req resource.ConfigureReq
 	r.client = providerData.client
 	r.organizationID = providerData.organizationID
 }
+
+// findProjectByName looks up the project with the given name in the
+// organization, going through all the pages of the project list.
+func findProjectByName(ctx context.Context, c client.ClientWithResponsesInterface, organizationID uuid.UUID, name string) (*client.Project, diag.Diagnostic) {
+	projects := client.ListAllProjects(ctx, c, organizationID)
+	for projects.Next() {
+		if project := projects.Value(); project.Name == name {
+			return &project, nil
+		}
+	}
+	if err := projects.Err(); err != nil {
+		return nil, diag.NewErrorDiagnostic(
+			fmt.Sprintf("Unable to Find Project %s", name),
+			fmt.Sprintf("ListProjects API returned error: %s", apiErrorDetail(err)),
+		)
+	}
+
+	return nil, diag.NewErrorDiagnostic(
+		fmt.Sprintf("Unable to Find Project %s", name),
+		fmt.Sprintf("No project named %s was found in the organization %s. Check the name, and that the project belongs to the organization the provider is configured with.", name, organizationID),
+	)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/thanhpk/randstr"
 )
@@ -21,6 +22,7 @@ func randomProjectName() string {
 func TestAccProject(t *testing.T) {
 	projName := randomProjectName()
 	projName2 := randomProjectName()
+	var projectID string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -33,7 +35,41 @@ func TestAccProject(t *testing.T) {
 			},
 			{
 				Config: genConfigWithProjectName(projName2),
-				Check:  resource.ComposeTestCheckFunc(testAccProjectExists(t, "deno_project.test")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccProjectExists(t, "deno_project.test"),
+					func(s *terraform.State) error {
+						projectID = s.RootModule().Resources["deno_project.test"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// A project deleted outside of Terraform is removed from the
+				// state and created again
+				PreConfig: func() {
+					resp, err := getAPIClient(t).DeleteProjectWithResponse(context.Background(), uuid.MustParse(projectID))
+					if err == nil {
+						err = client.CheckResponse(resp)
+					}
+					if err != nil {
+						t.Fatalf("failed to delete project %s: %s", projectID, err)
+					}
+				},
+				Config: genConfigWithProjectName(projName2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("deno_project.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					testAccProjectExists(t, "deno_project.test"),
+					resource.TestCheckResourceAttrWith("deno_project.test", "id", func(id string) error {
+						if id == projectID {
+							return fmt.Errorf("expected a new project, got the deleted one %s", id)
+						}
+						return nil
+					}),
+				),
 			},
 			{
 				Config: `
@@ -80,8 +116,8 @@ func testAccProjectExists(t *testing.T, resourceName string) resource.TestCheckF
 		if err != nil {
 			return fmt.Errorf("failed to get project %s: %s", rawProjectID, err)
 		}
-		if client.RespIsError(resp) {
-			return fmt.Errorf("project %s does not exist: %s", rawProjectID, client.APIErrorDetail(resp.HTTPResponse, resp.Body))
+		if err := client.CheckResponse(resp); err != nil {
+			return fmt.Errorf("project %s does not exist: %s", rawProjectID, err)
 		}
 		if resp.JSON200.Name != projectName {
 			return fmt.Errorf("project %s has name %s, expected %s", rawProjectID, resp.JSON200.Name, projectName)
@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"terraform-provider-deno/client"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &projectsDataSource{}
+	_ datasource.DataSourceWithConfigure      = &projectsDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &projectsDataSource{}
+)
+
+// projectObjectType is the type of each element of the projects list.
+var projectObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"id":         types.StringType,
+		"name":       types.StringType,
+		"created_at": types.StringType,
+		"updated_at": types.StringType,
+	},
+}
+
+// NewProjectsDataSource is a helper function to simplify the provider implementation.
+func NewProjectsDataSource() datasource.DataSource {
+	return &projectsDataSource{}
+}
+
+// projectsDataSource is the data source implementation.
+type projectsDataSource struct {
+	client         client.ClientWithResponsesInterface
+	organizationID uuid.UUID
+}
+
+// projectsDataSourceModel maps the data source schema data.
+type projectsDataSourceModel struct {
+	NamePrefix    types.String `tfsdk:"name_prefix"`
+	NameRegex     types.String `tfsdk:"name_regex"`
+	CreatedAfter  types.String `tfsdk:"created_after"`
+	CreatedBefore types.String `tfsdk:"created_before"`
+	Projects      types.List   `tfsdk:"projects"`
+}
+
+// projectsFilter is the parsed form of the filters of the data source.
+type projectsFilter struct {
+	namePrefix    string
+	nameRegex     *regexp.Regexp
+	createdAfter  *time.Time
+	createdBefore *time.Time
+}
+
+// Metadata returns the data source type name.
+func (d *projectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+// Schema defines the schema for the data source.
+func (d *projectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+A data source for the projects in the organization.
+
+All the projects are listed unless filters are set. When several filters are set, only the projects matching all of them are listed.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the projects whose names start with this prefix.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only list the projects whose names match this regular expression, in RE2 syntax.",
+				MarkdownDescription: "Only list the projects whose names match this regular expression, in [RE2 syntax](https://github.com/google/re2/wiki/Syntax).",
+			},
+			"created_after": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only list the projects created after this time, formatted in RFC3339.",
+				MarkdownDescription: "Only list the projects created after this time, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+			"created_before": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Only list the projects created before this time, formatted in RFC3339.",
+				MarkdownDescription: "Only list the projects created before this time, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+			},
+			"projects": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The projects, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the project.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "The name of the project.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The time the project was created, formatted in RFC3339.",
+							MarkdownDescription: "The time the project was created, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							Description:         "The time the project was last updated, formatted in RFC3339.",
+							MarkdownDescription: "The time the project was last updated, formatted in [RFC3339](https://datatracker.ietf.org/doc/html/rfc3339).",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the filters.
+func (d *projectsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var config projectsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags = config.filter()
+	resp.Diagnostics.Append(diags...)
+}
+
+// filter parses the filters set in the config. Unknown filters are ignored.
+func (m *projectsDataSourceModel) filter() (projectsFilter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	filter := projectsFilter{
+		namePrefix: m.NamePrefix.ValueString(),
+	}
+
+	if !m.NameRegex.IsNull() && !m.NameRegex.IsUnknown() {
+		re, err := regexp.Compile(m.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("Could not compile %s: %s", m.NameRegex, err.Error()),
+			)
+		}
+		filter.nameRegex = re
+	}
+
+	parseTime := func(name string, v types.String) *time.Time {
+		if v.IsNull() || v.IsUnknown() {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, v.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Invalid Time",
+				fmt.Sprintf("Could not parse %s as RFC3339: %s", v, err.Error()),
+			)
+			return nil
+		}
+		return &t
+	}
+	filter.createdAfter = parseTime("created_after", m.CreatedAfter)
+	filter.createdBefore = parseTime("created_before", m.CreatedBefore)
+
+	return filter, diags
+}
+
+// match returns true if the project passes all the filters.
+func (f projectsFilter) match(project client.Project) bool {
+	if !strings.HasPrefix(project.Name, f.namePrefix) {
+		return false
+	}
+	if f.nameRegex != nil && !f.nameRegex.MatchString(project.Name) {
+		return false
+	}
+	if f.createdAfter != nil && !project.CreatedAt.After(*f.createdAfter) {
+		return false
+	}
+	if f.createdBefore != nil && !project.CreatedAt.Before(*f.createdBefore) {
+		return false
+	}
+	return true
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *projectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state projectsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := state.filter()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var projects []client.Project
+	it := client.ListAllProjects(ctx, d.client, d.organizationID)
+	for it.Next() {
+		if project := it.Value(); filter.match(project) {
+			projects = append(projects, project)
+		}
+	}
+	if err := it.Err(); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Projects",
+			apiErrorDetail(err),
+		)
+		return
+	}
+
+	// Project names are unique, so they give a stable order
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	list, diags := convertToProjectsList(projects)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Projects = list
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func convertToProjectsList(projects []client.Project) (types.List, diag.Diagnostics) {
+	elems := make([]attr.Value, len(projects))
+	for i, project := range projects {
+		objectValue, diags := types.ObjectValue(projectObjectType.AttrTypes, map[string]attr.Value{
+			"id":         types.StringValue(project.Id.String()),
+			"name":       types.StringValue(project.Name),
+			"created_at": types.StringValue(project.CreatedAt.Format(time.RFC3339)),
+			"updated_at": types.StringValue(project.UpdatedAt.Format(time.RFC3339)),
+		})
+		if diags.HasError() {
+			return types.ListNull(projectObjectType), diags
+		}
+		elems[i] = objectValue
+	}
+
+	projectsList, diags := types.ListValue(projectObjectType, elems)
+	if diags.HasError() {
+		return types.ListNull(projectObjectType), diags
+	}
+
+	return projectsList, nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *projectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*deployProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+	d.organizationID = providerData.organizationID
+}
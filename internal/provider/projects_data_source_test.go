@@ -0,0 +1,78 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectsDataSource(t *testing.T) {
+	prefix := randomProjectName()[:10]
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccProjectDestroy(t),
+		Steps: []resource.TestStep{
+			{
+				// The invalid configuration comes first, so that the resources
+				// are destroyed with a valid one at the end of the test
+				Config: `
+					data "deno_projects" "test" {
+						name_regex = "("
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "deno_project" "b" {
+						name = "%[1]s-b"
+					}
+
+					resource "deno_project" "a" {
+						name = "%[1]s-a"
+					}
+
+					resource "deno_project" "other" {}
+				`, prefix),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "deno_project" "b" {
+						name = "%[1]s-b"
+					}
+
+					resource "deno_project" "a" {
+						name = "%[1]s-a"
+					}
+
+					resource "deno_project" "other" {}
+
+					data "deno_projects" "prefix" {
+						name_prefix = "%[1]s"
+					}
+
+					data "deno_projects" "regex" {
+						name_regex = "^%[1]s-[b-z]$"
+					}
+
+					data "deno_projects" "future" {
+						created_after = "2100-01-01T00:00:00Z"
+					}
+				`, prefix),
+				Check: resource.ComposeTestCheckFunc(
+					// Sorted by name
+					resource.TestCheckResourceAttr("data.deno_projects.prefix", "projects.#", "2"),
+					resource.TestCheckResourceAttrPair("data.deno_projects.prefix", "projects.0.id", "deno_project.a", "id"),
+					resource.TestCheckResourceAttrPair("data.deno_projects.prefix", "projects.1.id", "deno_project.b", "id"),
+					resource.TestCheckResourceAttr("data.deno_projects.regex", "projects.#", "1"),
+					resource.TestCheckResourceAttrPair("data.deno_projects.regex", "projects.0.name", "deno_project.b", "name"),
+					resource.TestCheckResourceAttr("data.deno_projects.future", "projects.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"terraform-provider-deno/client"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -36,18 +38,36 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// NewWithHTTPClient is like New, but the provider sends its requests through
+// the given doer instead of http.DefaultClient. This allows tests to record
+// and replay the HTTP traffic of the provider.
+func NewWithHTTPClient(version string, doer client.HttpRequestDoer) func() provider.Provider {
+	return func() provider.Provider {
+		return &deployProvider{
+			version:    version,
+			httpClient: doer,
+		}
+	}
+}
+
 // deployProvider is the provider implementation.
 type deployProvider struct {
 	// version is set to the provider version on release, "dev" when the
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+	// httpClient is the doer through which requests are sent. nil means
+	// http.DefaultClient.
+	httpClient client.HttpRequestDoer
 }
 
 // deployProviderData is the provider-defined data that is intended to pass to
 // data sources and resoures as ProviderData.
 type deployProviderData struct {
-	client         client.ClientWithResponsesInterface
+	client client.ClientWithResponsesInterface
+	// rawClient is the underlying client of client, which returns the raw
+	// HTTP response. This is needed for streaming endpoints.
+	rawClient      client.ClientInterface
 	organizationID uuid.UUID
 }
 
@@ -77,15 +97,30 @@ The terraform provider for Deno Deploy offering management on Deno projects, cus
 				Optional:    true,
 				Description: "URI for the Deno API. For normal use cases this value doesn't need to be set, in which case it defaults to https://api.deno.com/v1. May be set by the DENO_API_HOST environment variable.",
 			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times a request to the Deno API is retried when it fails due to a transient error, such as a connection error, a 429 or a 5xx response. Only idempotent requests are retried. Set 0 to disable retrying. Defaults to 3.",
+			},
+			"max_retry_wait": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum time to wait before retrying a failed request, such as `30s` or `1m`. The wait grows exponentially with each retry, or follows the Retry-After header of the response, up to this value. Defaults to 30s.",
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to check that the token is valid and can access the organization when the provider is configured, by fetching the organization. This makes misconfigurations fail early with a precise message, at the cost of an extra request. Defaults to false.",
+			},
 		},
 	}
 }
 
 // deployProviderModel maps provider schema data to a Go type.
 type deployProviderModel struct {
-	Host           types.String `tfsdk:"host"`
-	Token          types.String `tfsdk:"token"`
-	OrganizationID types.String `tfsdk:"organization_id"`
+	Host                types.String `tfsdk:"host"`
+	Token               types.String `tfsdk:"token"`
+	OrganizationID      types.String `tfsdk:"organization_id"`
+	MaxRetries          types.Int64  `tfsdk:"max_retries"`
+	MaxRetryWait        types.String `tfsdk:"max_retry_wait"`
+	ValidateCredentials types.Bool   `tfsdk:"validate_credentials"`
 }
 
 // Configure prepares a Deploy API client for data sources and resources.
@@ -153,6 +188,34 @@ func (p *deployProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	maxRetries := client.DEFAULT_MAX_RETRIES
+	if !config.MaxRetries.IsNull() {
+		maxRetries = int(config.MaxRetries.ValueInt64())
+		if maxRetries < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Max Retries",
+				fmt.Sprintf("max_retries must not be negative, got %d.", maxRetries),
+			)
+		}
+	}
+
+	maxRetryWait := client.DEFAULT_MAX_RETRY_WAIT
+	if config.MaxRetryWait.ValueString() != "" {
+		maxRetryWait, err = time.ParseDuration(config.MaxRetryWait.ValueString())
+		if err != nil || maxRetryWait < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retry_wait"),
+				"Invalid Max Retry Wait",
+				fmt.Sprintf("max_retry_wait must be a non-negative duration such as \"30s\", got %q.", config.MaxRetryWait.ValueString()),
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ctx = tflog.SetField(ctx, "deno_deploy_host", host)
 	ctx = tflog.SetField(ctx, "deno_deploy_token", token)
 	ctx = tflog.SetField(ctx, "deno_deploy_organization_id", organizationID)
@@ -165,7 +228,20 @@ func (p *deployProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 		return nil
 	}
-	client, err := client.NewClientWithResponses(host, client.WithRequestEditorFn(addAuth))
+	// Retry requests failed due to transient errors, logging each failed
+	// attempt along with its trace ID
+	var doer client.HttpRequestDoer = http.DefaultClient
+	if p.httpClient != nil {
+		doer = p.httpClient
+	}
+	httpClient := client.NewRetryingDoer(doer, client.RetryConfig{
+		MaxRetries: maxRetries,
+		MaxWait:    maxRetryWait,
+		Logger: func(ctx context.Context, msg string, fields map[string]any) {
+			tflog.Warn(ctx, msg, fields)
+		},
+	})
+	client, err := client.NewClientWithResponses(host, client.WithRequestEditorFn(addAuth), client.WithHTTPClient(httpClient))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create HashiCups API Client",
@@ -176,8 +252,16 @@ func (p *deployProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	if config.ValidateCredentials.ValueBool() {
+		resp.Diagnostics.Append(validateCredentials(ctx, client, host, organizationID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	data := &deployProviderData{
 		client:         client,
+		rawClient:      client.ClientInterface,
 		organizationID: organizationID,
 	}
 
@@ -189,10 +273,66 @@ func (p *deployProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	tflog.Info(ctx, "Configured Deno Deploy client", map[string]any{"success": true})
 }
 
+// validateCredentials fetches the organization to make sure that the token is
+// valid and has access to the organization.
+func validateCredentials(ctx context.Context, c client.ClientWithResponsesInterface, host string, organizationID uuid.UUID) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tflog.Debug(ctx, "Validating Deno Deploy credentials")
+
+	org, err := c.GetOrganizationWithResponse(ctx, organizationID)
+	if err != nil {
+		diags.AddError(
+			"Unable to Validate Deno Deploy Credentials",
+			fmt.Sprintf("Could not reach the Deno Deploy API at %s: %s", host, err.Error()),
+		)
+		return diags
+	}
+	if err := client.CheckResponse(org); err != nil {
+		switch {
+		case client.IsUnauthorized(err):
+			diags.AddAttributeError(
+				path.Root("token"),
+				"Invalid Deno Deploy API Token",
+				apiErrorDetail(err),
+			)
+		case org.StatusCode() == http.StatusNotFound, org.StatusCode() == http.StatusForbidden:
+			// The status code is checked rather than client.IsNotFound, since
+			// a 404 without an error code still means the organization is
+			// missing here
+			diags.AddAttributeError(
+				path.Root("organization_id"),
+				"Inaccessible Deno Deploy Organization",
+				fmt.Sprintf("The organization %s does not exist, or the access token cannot access it. Check the organization_id attribute of the provider or the DENO_DEPLOY_ORGANIZATION_ID environment variable, and that the token belongs to a member of the organization.\n\n%s", organizationID, apiErrorDetail(err)),
+			)
+		default:
+			diags.AddError(
+				"Unable to Validate Deno Deploy Credentials",
+				apiErrorDetail(err),
+			)
+		}
+		return diags
+	}
+
+	tflog.Debug(ctx, "Validated Deno Deploy credentials", map[string]any{
+		"organization_name": org.JSON200.Name,
+	})
+	return diags
+}
+
 // DataSources defines the data sources implemented in the provider.
 func (p *deployProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAssetsResource,
+		NewProjectDataSource,
+		NewProjectsDataSource,
+		NewOrganizationDataSource,
+		NewDeploymentsDataSource,
+		NewDeploymentDataSource,
+		NewDomainDataSource,
+		NewDomainsDataSource,
+		NewAppLogsDataSource,
+		NewProjectAnalyticsDataSource,
 	}
 }
 
@@ -203,6 +343,8 @@ func (p *deployProvider) Resources(_ context.Context) []func() resource.Resource
 		NewDomainResource,
 		NewDomainVerificationResource,
 		NewCertificateProvisioningResource,
+		NewCustomCertificateResource,
 		NewDeploymentResource,
+		NewDomainAssociationResource,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"terraform-provider-deno/client"
+	"terraform-provider-deno/internal/fakedeploy"
 	"terraform-provider-deno/internal/provider"
 	"testing"
 
@@ -16,12 +17,43 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
+// TestMain starts the fake Deno Deploy API server and points the provider at
+// it, unless a real token is given through DENO_DEPLOY_TOKEN. This allows
+// running the acceptance tests offline and in CI without access to secrets.
+//
+// When replaying cassettes, neither the fake nor the real API is used. See
+// cassette_test.go for details.
+func TestMain(m *testing.M) {
+	if cassetteMode() == cassetteModeReplay {
+		setReplayEnv()
+		os.Exit(m.Run())
+	}
+	if os.Getenv("DENO_DEPLOY_TOKEN") != "" {
+		os.Exit(m.Run())
+	}
+
+	fakeServer = fakedeploy.NewServer()
+	serveTestApps(fakeServer)
+	os.Setenv("DEPLOY_API_HOST", fakeServer.URL)
+	os.Setenv("DENO_DEPLOY_TOKEN", fakeServer.Token)
+	os.Setenv("DENO_DEPLOY_ORGANIZATION_ID", fakeServer.OrganizationID.String())
+
+	code := m.Run()
+	fakeServer.Close()
+	os.Exit(code)
+}
+
+// fakeServer is the fake Deno Deploy API server the tests run against, or nil
+// if they run against the real API. Tests can use it to set up data that can't
+// be created through the API, such as app logs.
+var fakeServer *fakedeploy.Server
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"deno": providerserver.NewProtocol6WithError(provider.New("test")()),
+	"deno": providerserver.NewProtocol6WithError(provider.NewWithHTTPClient("test", testHTTPClient{})()),
 }
 
 var apiClient client.ClientWithResponsesInterface
@@ -33,7 +65,7 @@ func getAPIClient(t *testing.T) client.ClientWithResponsesInterface {
 			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 			return nil
 		}
-		c, err := client.NewClientWithResponses(os.Getenv("DEPLOY_API_HOST"), client.WithRequestEditorFn(addAuth))
+		c, err := client.NewClientWithResponses(os.Getenv("DEPLOY_API_HOST"), client.WithRequestEditorFn(addAuth), client.WithHTTPClient(testHTTPClient{}))
 		if err != nil {
 			t.Fatalf("failed to create Deno Deploy API client: %s", err)
 		}
@@ -47,6 +79,7 @@ func testAccPreCheck(t *testing.T) {
 	ensureEnvVarExist(t, "DENO_DEPLOY_TOKEN")
 	ensureEnvVarExist(t, "DEPLOY_API_HOST")
 	ensureEnvVarExist(t, "DENO_DEPLOY_ORGANIZATION_ID")
+	useCassette(t)
 }
 
 func ensureEnvVarExist(t *testing.T, name string) {
@@ -5,7 +5,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"strings"
+
+	"terraform-provider-deno/client"
 )
 
 // encodePath applies URL encoding to the given path, with directory separator
@@ -26,3 +29,41 @@ func calculateGitSha1(b []byte) string {
 	h.Write(b)
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// relativeToRoot returns the given path relative to the root directory, with
+// the directory separator normalized to `/`. Both the root directory and the
+// path are either absolute or relative to the current working directory.
+func relativeToRoot(rootDir string, path string) (string, error) {
+	absRoot, err := filepath.Abs(rootDir)
+	if err != nil {
+		return "", err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(absRoot, absPath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// isOutsideRoot returns true if the given path, which is relative to some root
+// directory, points outside of the root directory.
+func isOutsideRoot(rel string) bool {
+	return rel == ".." || strings.HasPrefix(rel, "../")
+}
+
+// apiErrorDetail returns the detail of a diagnostic for an error returned by
+// the Deno Deploy API, with advice on how to resolve it for the common causes.
+func apiErrorDetail(err error) string {
+	switch {
+	case client.IsUnauthorized(err):
+		return "The access token is invalid or has expired. Create a new one at https://dash.deno.com/account#access-tokens, and set it to the token attribute of the provider or the DENO_DEPLOY_TOKEN environment variable.\n\n" + err.Error()
+	case client.IsRateLimited(err):
+		return "Too many requests have been sent to the Deno Deploy API. Wait for a while and try again, or increase max_retries and max_retry_wait of the provider.\n\n" + err.Error()
+	default:
+		return err.Error()
+	}
+}
@@ -1,6 +1,9 @@
 package provider
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+)
 
 func TestEncodePath(t *testing.T) {
 	tests := []struct {
@@ -80,3 +83,78 @@ func TestCalculateGitSha1(t *testing.T) {
 		})
 	}
 }
+
+func TestRelativeToRoot(t *testing.T) {
+	abs, err := filepath.Abs("app/main.ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		rootDir       string
+		input         string
+		expected      string
+		outsideOfRoot bool
+	}{
+		{
+			name:     "current directory as root",
+			rootDir:  ".",
+			input:    "main.ts",
+			expected: "main.ts",
+		},
+		{
+			name:     "redundant elements are cleaned",
+			rootDir:  ".",
+			input:    "./src/../main.ts",
+			expected: "main.ts",
+		},
+		{
+			name:          "parent directory with current directory as root",
+			rootDir:       ".",
+			input:         "../main.ts",
+			expected:      "../main.ts",
+			outsideOfRoot: true,
+		},
+		{
+			name:     "parent directory as root",
+			rootDir:  "..",
+			input:    "../main.ts",
+			expected: "main.ts",
+		},
+		{
+			name:     "nested directory as root",
+			rootDir:  "app",
+			input:    "app/routes/index.tsx",
+			expected: "routes/index.tsx",
+		},
+		{
+			name:     "absolute path",
+			rootDir:  "app",
+			input:    abs,
+			expected: "main.ts",
+		},
+		{
+			name:          "outside of root",
+			rootDir:       "app",
+			input:         "main.ts",
+			expected:      "../main.ts",
+			outsideOfRoot: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := relativeToRoot(tt.rootDir, tt.input)
+			if err != nil {
+				t.Fatalf("relativeToRoot() returned error: %s", err)
+			}
+			if got != tt.expected {
+				t.Errorf("relativeToRoot() = %v, want %v", got, tt.expected)
+			}
+			if isOutsideRoot(got) != tt.outsideOfRoot {
+				t.Errorf("isOutsideRoot() = %v, want %v", isOutsideRoot(got), tt.outsideOfRoot)
+			}
+		})
+	}
+}